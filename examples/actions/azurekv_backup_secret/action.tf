@@ -0,0 +1,18 @@
+action "azurekv_backup_secret" "example" {
+  config {
+    name         = azurekv_secret.example.name
+    key_vault_id = azurekv_secret.example.key_vault_id
+    output_path  = "${path.root}/backups/${azurekv_secret.example.name}.bak"
+  }
+}
+
+resource "terraform_data" "backup" {
+  input = azurekv_secret.example.version
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.azurekv_backup_secret.example]
+    }
+  }
+}
@@ -0,0 +1,8 @@
+# Run with `terraform apply -invoke=action.azurekv_sync_secret.example`
+action "azurekv_sync_secret" "example" {
+  config {
+    name                     = "database-password"
+    source_key_vault_id      = data.azurerm_key_vault.staging.id
+    destination_key_vault_id = data.azurerm_key_vault.production.id
+  }
+}
@@ -0,0 +1,10 @@
+data "azurekv_data_plane_roles" "example" {
+  key_vault_id = data.azurerm_key_vault.existing.id
+
+  lifecycle {
+    postcondition {
+      condition     = contains(self.role_names, "Key Vault Secrets Officer") && !contains(self.role_names, "Key Vault Administrator")
+      error_message = "The principal ${self.principal_id} must hold Key Vault Secrets Officer and must not hold Key Vault Administrator."
+    }
+  }
+}
@@ -0,0 +1,12 @@
+data "azurekv_deleted_secret" "example" {
+  name         = "secret-sauce"
+  key_vault_id = data.azurerm_key_vault.existing.id
+}
+
+output "secret_recoverable" {
+  value = data.azurekv_deleted_secret.example.recoverable
+}
+
+output "secret_scheduled_purge_date" {
+  value = data.azurekv_deleted_secret.example.scheduled_purge_date
+}
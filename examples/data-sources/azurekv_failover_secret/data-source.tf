@@ -0,0 +1,15 @@
+data "azurekv_failover_secret" "example" {
+  name = "secret-sauce"
+  key_vault_ids = [
+    data.azurerm_key_vault.primary.id,
+    data.azurerm_key_vault.secondary.id,
+  ]
+}
+
+output "secret_key_vault_id" {
+  value = data.azurekv_failover_secret.example.key_vault_id
+}
+
+output "secret_versionless_id" {
+  value = data.azurekv_failover_secret.example.versionless_id
+}
@@ -0,0 +1,10 @@
+data "azurekv_key_vault" "example" {
+  key_vault_id = data.azurerm_key_vault.existing.id
+
+  lifecycle {
+    postcondition {
+      condition     = self.purge_protection_enabled && self.rbac_authorization_enabled && !self.public_network_access_enabled
+      error_message = "The Key Vault ${self.name} must enable purge protection and RBAC authorization and disable public network access."
+    }
+  }
+}
@@ -0,0 +1,15 @@
+data "azurekv_secret_statistics" "example" {
+  key_vault_id         = data.azurerm_key_vault.existing.id
+  expiring_within_days = 14
+}
+
+check "secret_expiration" {
+  assert {
+    condition     = data.azurekv_secret_statistics.example.expiring_count == 0
+    error_message = "${data.azurekv_secret_statistics.example.expiring_count} secrets expire within 14 days."
+  }
+}
+
+output "secret_count_by_content_type" {
+  value = data.azurekv_secret_statistics.example.count_by_content_type
+}
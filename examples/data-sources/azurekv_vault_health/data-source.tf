@@ -0,0 +1,10 @@
+data "azurekv_vault_health" "example" {
+  key_vault_id = data.azurerm_key_vault.existing.id
+}
+
+check "key_vault" {
+  assert {
+    condition     = data.azurekv_vault_health.example.healthy
+    error_message = "The Key Vault can't be used: ${jsonencode(data.azurekv_vault_health.example)}"
+  }
+}
@@ -0,0 +1,4 @@
+ephemeral "azurekv_certificate_private_key" "example" {
+  name         = azurerm_key_vault_certificate.example.name
+  key_vault_id = azurerm_key_vault_certificate.example.key_vault_id
+}
@@ -0,0 +1,7 @@
+list "azurekv_key_vault" "example" {
+  provider = azurekv
+
+  config {
+    resource_group_name = "example-resources"
+  }
+}
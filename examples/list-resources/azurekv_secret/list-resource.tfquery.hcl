@@ -0,0 +1,8 @@
+# `terraform query -generate-config-out=generated.tf` generates the resources and import blocks for all the secrets
+list "azurekv_secret" "example" {
+  provider = azurekv
+
+  config {
+    key_vault_id = "/subscriptions/e14d31c7-5870-40ce-9ef9-774df224e61d/resourceGroups/example-resourcegroup/providers/Microsoft.KeyVault/vaults/example-keyvault"
+  }
+}
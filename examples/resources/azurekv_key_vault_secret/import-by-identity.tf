@@ -0,0 +1,10 @@
+# `terraform plan -generate-config-out=generated.tf` generates a configuration that doesn't manage the value.
+# To manage the value, add value_wo to it, which is written when value_wo_version is changed from 1.
+# To pin a version, add version to the identity and pinned_version with the same version to the configuration.
+import {
+  to = azurekv_key_vault_secret.example
+  identity = {
+    name         = "example"
+    key_vault_id = "/subscriptions/e14d31c7-5870-40ce-9ef9-774df224e61d/resourceGroups/example-resourcegroup/providers/Microsoft.KeyVault/vaults/example-keyvault"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = azurekv_key_vault_secret.example
+  id = "https://example-keyvault.vault.azure.net/secrets/example"
+}
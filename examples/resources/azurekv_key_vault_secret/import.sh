@@ -0,0 +1,4 @@
+terraform import azurekv_key_vault_secret.example "https://example-keyvault.vault.azure.net/secrets/example"
+
+# The versioned ID pins the version, which requires pinned_version with the same version in the configuration
+terraform import azurekv_key_vault_secret.example "https://example-keyvault.vault.azure.net/secrets/example/fdf067c93bbb4b22bff4d8b7a9a56217"
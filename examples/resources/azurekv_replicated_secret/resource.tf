@@ -0,0 +1,9 @@
+resource "azurekv_replicated_secret" "example" {
+  name = "secret-sauce"
+  key_vault_ids = [
+    azurerm_key_vault.east.id,
+    azurerm_key_vault.west.id,
+  ]
+  value_wo         = "szechuan"
+  value_wo_version = 1
+}
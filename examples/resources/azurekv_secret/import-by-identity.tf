@@ -1,3 +1,6 @@
+# `terraform plan -generate-config-out=generated.tf` generates a configuration that doesn't manage the value.
+# To manage the value, add value_wo to it, which is written when value_wo_version is changed from 1.
+# To pin a version, add version to the identity and pinned_version with the same version to the configuration.
 import {
   to = azurekv_secret.example
   identity = {
@@ -1,4 +1,4 @@
 import {
   to = azurekv_secret.example
-  id = "https://example-keyvault.vault.azure.net/secrets/example/fdf067c93bbb4b22bff4d8b7a9a56217"
+  id = "https://example-keyvault.vault.azure.net/secrets/example"
 }
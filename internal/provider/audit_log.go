@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The operations recorded in the audit log.
+const (
+	auditOperationSet     = "set"
+	auditOperationUpdate  = "update"
+	auditOperationDelete  = "delete"
+	auditOperationPurge   = "purge"
+	auditOperationRecover = "recover"
+)
+
+// auditLog appends a JSON line for each write operation to a file, as evidence for change control.
+// Secret values are never recorded.
+type auditLog struct {
+	file  string
+	mutex sync.Mutex
+	// caller returns the identity of the caller, which is resolved on first use
+	caller func() *auditCaller
+}
+
+// auditEntry is a line of the audit log.
+type auditEntry struct {
+	Time       string       `json:"time"`
+	Operation  string       `json:"operation"`
+	KeyVaultID string       `json:"key_vault_id"`
+	Name       string       `json:"name"`
+	Version    string       `json:"version,omitempty"`
+	Caller     *auditCaller `json:"caller,omitempty"`
+}
+
+// auditCaller is the identity of the caller extracted from the claims of the access token.
+type auditCaller struct {
+	TenantID      string `json:"tenant_id,omitempty"`
+	ObjectID      string `json:"object_id,omitempty"`
+	AppID         string `json:"app_id,omitempty"`
+	PrincipalName string `json:"principal_name,omitempty"`
+}
+
+// newAuditLog returns nil if file is empty, which means no audit log.
+func newAuditLog(file string, credential func() (azcore.TokenCredential, error)) *auditLog {
+	if file == "" {
+		return nil
+	}
+
+	return &auditLog{
+		file: file,
+		caller: sync.OnceValue(func() *auditCaller {
+			cred, err := credential()
+			if err != nil {
+				return nil
+			}
+			// The token is cached by the credential, so this usually doesn't call Microsoft Entra ID
+			token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://vault.azure.net/.default"}})
+			if err != nil {
+				return nil
+			}
+			return callerFromToken(token.Token)
+		}),
+	}
+}
+
+// record appends the entry of the operation to the audit log.
+// The operation has already been performed, so a failure to write only logs a warning.
+func (l *auditLog) record(ctx context.Context, operation, keyVaultID, name, version string) {
+	if l == nil {
+		return
+	}
+
+	entry := auditEntry{
+		Time:       time.Now().UTC().Format(time.RFC3339Nano),
+		Operation:  operation,
+		KeyVaultID: keyVaultID,
+		Name:       name,
+		Version:    version,
+		Caller:     l.caller(),
+	}
+	if err := l.append(entry); err != nil {
+		tflog.Warn(ctx, "Failed to write the audit log", map[string]any{"path": l.file, "error": err.Error()})
+	}
+}
+
+func (l *auditLog) append(entry auditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Each line is written at once with O_APPEND, so that the lines of concurrent provider processes are not interleaved
+	f, err := os.OpenFile(l.file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// callerFromToken extracts the identity of the caller from the claims of a JWT access token without verifying it,
+// or returns nil if the token is not a JWT.
+func callerFromToken(token string) *auditCaller {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		TenantID   string `json:"tid"`
+		ObjectID   string `json:"oid"`
+		AppID      string `json:"appid"`
+		UPN        string `json:"upn"`
+		UniqueName string `json:"unique_name"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+
+	caller := &auditCaller{
+		TenantID:      claims.TenantID,
+		ObjectID:      claims.ObjectID,
+		AppID:         claims.AppID,
+		PrincipalName: claims.UPN,
+	}
+	if caller.PrincipalName == "" {
+		caller.PrincipalName = claims.UniqueName
+	}
+	return caller
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+func testJWT(claims string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestCallerFromToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+		want  *auditCaller
+	}{
+		{
+			name:  "user",
+			token: testJWT(`{"tid":"tenant-id","oid":"object-id","upn":"user@example.com"}`),
+			want:  &auditCaller{TenantID: "tenant-id", ObjectID: "object-id", PrincipalName: "user@example.com"},
+		},
+		{
+			name:  "service principal",
+			token: testJWT(`{"tid":"tenant-id","oid":"object-id","appid":"app-id"}`),
+			want:  &auditCaller{TenantID: "tenant-id", ObjectID: "object-id", AppID: "app-id"},
+		},
+		{
+			name:  "guest user",
+			token: testJWT(`{"oid":"object-id","unique_name":"live.com#user@example.com"}`),
+			want:  &auditCaller{ObjectID: "object-id", PrincipalName: "live.com#user@example.com"},
+		},
+		{
+			name:  "not a JWT",
+			token: "token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := callerFromToken(tt.token)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("callerFromToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogRecord(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "audit.jsonl")
+	credential := func() (azcore.TokenCredential, error) {
+		return &staticTokenCredential{token: testJWT(`{"tid":"tenant-id","oid":"object-id","appid":"app-id"}`)}, nil
+	}
+	audit := newAuditLog(file, credential)
+	audit.record(context.Background(), auditOperationSet, testKeyVaultID, "secret-name", "version")
+	audit.record(context.Background(), auditOperationDelete, testKeyVaultID, "secret-name", "")
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	for i, want := range []auditEntry{
+		{Operation: auditOperationSet, KeyVaultID: testKeyVaultID, Name: "secret-name", Version: "version"},
+		{Operation: auditOperationDelete, KeyVaultID: testKeyVaultID, Name: "secret-name"},
+	} {
+		got := entries[i]
+		if got.Time == "" || got.Operation != want.Operation || got.KeyVaultID != want.KeyVaultID || got.Name != want.Name || got.Version != want.Version {
+			t.Errorf("entries[%d] = %+v, want %+v", i, got, want)
+		}
+		if got.Caller == nil || got.Caller.AppID != "app-id" {
+			t.Errorf("entries[%d].Caller = %+v, want the caller with the app ID", i, got.Caller)
+		}
+	}
+
+	if newAuditLog("", credential) != nil {
+		t.Error("newAuditLog() returned an audit log for an empty file")
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// automaticTagData is the data available in the templates of automatic_tags.
+type automaticTagData struct {
+	// Workspace is the name of the selected Terraform workspace.
+	Workspace string
+	// ProviderVersion is the version of this provider.
+	ProviderVersion string
+}
+
+// renderAutomaticTags renders the values of the automatic tags as Go templates.
+func renderAutomaticTags(automaticTags map[string]string, data automaticTagData) (map[string]string, error) {
+	rendered := make(map[string]string, len(automaticTags))
+	for k, v := range automaticTags {
+		tmpl, err := template.New(k).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the value of the tag %q: %w", k, err)
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, fmt.Errorf("failed to render the value of the tag %q: %w", k, err)
+		}
+		rendered[k] = sb.String()
+	}
+	return rendered, nil
+}
+
+// mergeAutomaticTags returns the automatic tags overridden by the default tags.
+func mergeAutomaticTags(automaticTags, defaultTags map[string]string) map[string]string {
+	merged := maps.Clone(automaticTags)
+	maps.Copy(merged, defaultTags)
+	return merged
+}
+
+// terraformWorkspace returns the name of the selected Terraform workspace.
+// Terraform doesn't pass it to providers, so it is resolved in the same way as Terraform does,
+// i.e. from TF_WORKSPACE or the environment file in the data directory of the working directory.
+func terraformWorkspace() (string, error) {
+	if v := os.Getenv("TF_WORKSPACE"); v != "" {
+		return v, nil
+	}
+
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = ".terraform"
+	}
+	b, err := os.ReadFile(filepath.Join(dataDir, "environment"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "default", nil
+		}
+		return "", err
+	}
+	if workspace := strings.TrimSpace(string(b)); workspace != "" {
+		return workspace, nil
+	}
+	return "default", nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderAutomaticTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		automaticTags map[string]string
+		want          map[string]string
+		wantErr       bool
+	}{
+		{
+			name: "templates",
+			automaticTags: map[string]string{
+				"managed-by":   "terraform",
+				"tf-workspace": "{{ .Workspace }}",
+				"tf-provider":  "azurekv/{{ .ProviderVersion }}",
+			},
+			want: map[string]string{
+				"managed-by":   "terraform",
+				"tf-workspace": "production",
+				"tf-provider":  "azurekv/1.2.3",
+			},
+		},
+		{
+			name:          "invalid template",
+			automaticTags: map[string]string{"tf-workspace": "{{ .Workspace"},
+			wantErr:       true,
+		},
+		{
+			name:          "unknown field",
+			automaticTags: map[string]string{"tf-workspace": "{{ .Unknown }}"},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := renderAutomaticTags(tt.automaticTags, automaticTagData{Workspace: "production", ProviderVersion: "1.2.3"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("renderAutomaticTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !maps.Equal(got, tt.want) {
+				t.Errorf("renderAutomaticTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeAutomaticTags(t *testing.T) {
+	t.Parallel()
+
+	got := mergeAutomaticTags(
+		map[string]string{"managed-by": "terraform", "env": "dev"},
+		map[string]string{"env": "prd"},
+	)
+	want := map[string]string{"managed-by": "terraform", "env": "prd"}
+	if !maps.Equal(got, want) {
+		t.Errorf("mergeAutomaticTags() = %v, want %v", got, want)
+	}
+}
+
+func TestTerraformWorkspace(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("TF_DATA_DIR", dataDir)
+
+	t.Setenv("TF_WORKSPACE", "")
+	got, err := terraformWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "default" {
+		t.Errorf("terraformWorkspace() = %q, want %q", got, "default")
+	}
+
+	if err := os.WriteFile(filepath.Join(dataDir, "environment"), []byte("staging"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = terraformWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "staging" {
+		t.Errorf("terraformWorkspace() = %q, want %q", got, "staging")
+	}
+
+	t.Setenv("TF_WORKSPACE", "production")
+	got, err = terraformWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "production" {
+		t.Errorf("terraformWorkspace() = %q, want %q", got, "production")
+	}
+}
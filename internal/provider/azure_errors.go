@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// The data actions required by the operations, which are shown when the caller lacks them.
+const (
+	dataActionBackup       = "Microsoft.KeyVault/vaults/secrets/backup/action"
+	dataActionDelete       = "Microsoft.KeyVault/vaults/secrets/delete"
+	dataActionGetSecret    = "Microsoft.KeyVault/vaults/secrets/getSecret/action"
+	dataActionPurge        = "Microsoft.KeyVault/vaults/secrets/purge/action"
+	dataActionReadDeleted  = "Microsoft.KeyVault/vaults/deletedSecrets/read"
+	dataActionReadMetadata = "Microsoft.KeyVault/vaults/secrets/readMetadata/action"
+	dataActionSetSecret    = "Microsoft.KeyVault/vaults/secrets/setSecret/action"
+	dataActionUpdate       = "Microsoft.KeyVault/vaults/secrets/update/action"
+)
+
+// builtInRoles are the least privileged built-in roles including the data actions.
+var builtInRoles = map[string]string{
+	dataActionGetSecret:    "Key Vault Secrets User",
+	dataActionReadMetadata: "Key Vault Reader",
+	dataActionReadDeleted:  "Key Vault Reader",
+}
+
+// azureErrorHint returns the guidance for the common errors of Azure followed by the details of the failed request,
+// prefixed with blank lines so that it can be appended to the detail of a diagnostic,
+// or an empty string if there is nothing to add. dataAction is the data action required by the operation that failed.
+func azureErrorHint(err error, dataAction string) string {
+	var hint string
+	if guidance := azureErrorGuidance(err, dataAction); guidance != "" {
+		hint = "\n\n" + guidance
+	}
+	return hint + azureRequestDetails(err)
+}
+
+// azureRequestDetails returns the vault name, the operation, and the request IDs of the failed request,
+// prefixed with blank lines, or an empty string if the error has no response.
+// The IDs can be used to find the request in the AuditEvent logs or in a support request without rerunning Terraform with TF_LOG=debug.
+func azureRequestDetails(err error) string {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) || respErr.RawResponse == nil {
+		return ""
+	}
+
+	var details []string
+	if req := respErr.RawResponse.Request; req != nil && req.URL != nil {
+		if vault, ok := vaultRequestOf(req); ok {
+			details = append(details, "Vault name: "+vault.vaultName)
+		}
+		details = append(details, "Operation: "+operationName(req))
+		if id := req.Header.Get("x-ms-client-request-id"); id != "" {
+			details = append(details, "Client request ID: "+id)
+		}
+	}
+	for _, header := range []string{"x-ms-request-id", "x-ms-correlation-request-id"} {
+		if id := respErr.RawResponse.Header.Get(header); id != "" {
+			details = append(details, "Request ID: "+id)
+			break
+		}
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(details, "\n")
+}
+
+func azureErrorGuidance(err error, dataAction string) string {
+	if isDNSOrConnectionError(err) {
+		return "The key vault can't be resolved or connected. Make sure that the key vault exists. " +
+			"If it has a private endpoint, make sure that the private DNS zone privatelink.vaultcore.azure.net is linked to the network running Terraform. " +
+			"If the key vault has just been created, set dns_propagation_timeout to wait for the DNS records to propagate."
+	}
+
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) {
+		return ""
+	}
+
+	switch respErr.StatusCode {
+	case http.StatusUnauthorized:
+		return "The request was not authenticated. Make sure that the credentials, such as AZURE_CLIENT_ID, AZURE_CLIENT_SECRET, and AZURE_TENANT_ID, are valid " +
+			"and that the tenant of the credentials is the tenant of the key vault."
+	case http.StatusForbidden:
+		if hasAzureErrorCode(respErr, "ForbiddenByFirewall") {
+			return "The request was blocked by the network ACLs of the key vault. " +
+				"Allow the public IP address running Terraform in the firewall of the key vault, or run Terraform in a network with access to its private endpoint."
+		}
+		role := builtInRoles[dataAction]
+		if role == "" {
+			role = "Key Vault Secrets Officer"
+		}
+		if hasAzureErrorCode(respErr, "ForbiddenByRbac") {
+			return fmt.Sprintf("The caller lacks the permission %s. Assign a role including it, such as %q, to the caller on the key vault. "+
+				"Role assignments take a few minutes to propagate, so set rbac_propagation_timeout if the role has just been assigned.", dataAction, role)
+		}
+		if hasAzureErrorCode(respErr, "ForbiddenByPolicy") || hasAzureErrorCode(respErr, "AccessDenied") {
+			return fmt.Sprintf("The access policies of the key vault don't grant the caller the permission %s. "+
+				"Add the secret permission to the access policy of the caller, or migrate the key vault to Azure RBAC and assign a role such as %q.", dataAction, role)
+		}
+		return fmt.Sprintf("The caller lacks the permission %s. Grant it with a role such as %q or an access policy of the key vault.", dataAction, role)
+	case http.StatusConflict:
+		if hasAzureErrorCode(respErr, "ObjectIsBeingDeleted") {
+			return "A secret with the same name is being deleted or purged. " +
+				"Set purge_wait_timeout to retry until the deletion completes, or wait and apply again."
+		}
+		if isSoftDeletedConflictError(err) {
+			return "A secret with the same name is deleted but recoverable. " +
+				"Set recover_soft_deleted_secrets to true to recover it, or purge it before creating the secret."
+		}
+	case http.StatusTooManyRequests:
+		return "Key Vault throttled the requests. Set max_requests_per_second or max_concurrent_requests, or reduce the -parallelism option of Terraform, " +
+			"so that the requests stay within the service limits of Key Vault."
+	}
+	return ""
+}
+
+// azureErrorBody is the body of an error response, e.g. {"error":{"code":"Forbidden","innererror":{"code":"ForbiddenByRbac"}}}.
+type azureErrorBody struct {
+	Error *azureErrorDetail `json:"error"`
+}
+
+type azureErrorDetail struct {
+	Code       string            `json:"code"`
+	InnerError *azureErrorDetail `json:"innererror"`
+}
+
+// hasAzureErrorCode reports whether the error has the code as either the error code or an inner error code.
+func hasAzureErrorCode(respErr *azcore.ResponseError, code string) bool {
+	if respErr.ErrorCode == code {
+		return true
+	}
+	if respErr.RawResponse == nil {
+		return false
+	}
+
+	// The body was read into memory by azcore when the error was created, so it can be read again
+	payload, err := runtime.Payload(respErr.RawResponse)
+	if err != nil {
+		return false
+	}
+	var body azureErrorBody
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return false
+	}
+	for detail := body.Error; detail != nil; detail = detail.InnerError {
+		if detail.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretUnavailableError returns whether the error means that the secret should be read from the next key vault,
+// namely that the secret doesn't exist or that the key vault can't be resolved or connected.
+func isSecretUnavailableError(err error) bool {
+	return isNotFoundError(err) || isDNSOrConnectionError(err)
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func newTestResponseError(t *testing.T, statusCode int, body string) error {
+	t.Helper()
+
+	ctx := context.WithValue(context.Background(), vaultRequestKey{}, testVaultRequest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testVaultURL+"/secrets/secret-name", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ms-client-request-id", "00000000-0000-0000-0000-000000000001")
+	return runtime.NewResponseError(&http.Response{
+		StatusCode: statusCode,
+		Header: http.Header{
+			"Content-Type":    []string{"application/json"},
+			"X-Ms-Request-Id": []string{"00000000-0000-0000-0000-000000000002"},
+		},
+		Body:    io.NopCloser(strings.NewReader(body)),
+		Request: req,
+	})
+}
+
+func TestAzureErrorHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		dataAction string
+		want       []string
+	}{
+		{
+			name:       "DNS error",
+			err:        fmt.Errorf("failed to get: %w", &net.DNSError{Err: "no such host", Name: "vault-name.vault.azure.net", IsNotFound: true}),
+			dataAction: dataActionReadMetadata,
+			want:       []string{"privatelink.vaultcore.azure.net", "dns_propagation_timeout"},
+		},
+		{
+			name:       "unauthorized",
+			err:        newTestResponseError(t, http.StatusUnauthorized, `{"error":{"code":"Unauthorized","message":"AKV10032: Invalid issuer."}}`),
+			dataAction: dataActionReadMetadata,
+			want:       []string{"AZURE_TENANT_ID"},
+		},
+		{
+			name:       "forbidden by firewall",
+			err:        newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"Client address is not authorized","innererror":{"code":"ForbiddenByFirewall"}}}`),
+			dataAction: dataActionSetSecret,
+			want:       []string{"network ACLs"},
+		},
+		{
+			name:       "forbidden by RBAC",
+			err:        newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"Caller is not authorized","innererror":{"code":"ForbiddenByRbac"}}}`),
+			dataAction: dataActionGetSecret,
+			want:       []string{dataActionGetSecret, `"Key Vault Secrets User"`, "rbac_propagation_timeout"},
+		},
+		{
+			name:       "forbidden by access policies",
+			err:        newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"The user does not have secrets set permission","innererror":{"code":"AccessDenied"}}}`),
+			dataAction: dataActionSetSecret,
+			want:       []string{"access policies", dataActionSetSecret, `"Key Vault Secrets Officer"`},
+		},
+		{
+			name:       "soft-deleted conflict",
+			err:        newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","message":"Secret is currently in a deleted but recoverable state","innererror":{"code":"ObjectIsDeletedButRecoverable"}}}`),
+			dataAction: dataActionSetSecret,
+			want:       []string{"recover_soft_deleted_secrets"},
+		},
+		{
+			name:       "being deleted conflict",
+			err:        newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","message":"Secret is currently being deleted","innererror":{"code":"ObjectIsBeingDeleted"}}}`),
+			dataAction: dataActionSetSecret,
+			want:       []string{"purge_wait_timeout"},
+		},
+		{
+			name:       "throttled",
+			err:        newTestResponseError(t, http.StatusTooManyRequests, `{"error":{"code":"Throttled","message":"Request was not processed because too many requests were received."}}`),
+			dataAction: dataActionReadMetadata,
+			want:       []string{"max_requests_per_second", "max_concurrent_requests"},
+		},
+		{
+			name:       "not found",
+			err:        newTestResponseError(t, http.StatusNotFound, `{"error":{"code":"SecretNotFound","message":"A secret with (name/id) secret-name was not found in this key vault."}}`),
+			dataAction: dataActionReadMetadata,
+			want:       []string{"Request ID: 00000000-0000-0000-0000-000000000002"},
+		},
+		{
+			name:       "other error",
+			err:        io.ErrUnexpectedEOF,
+			dataAction: dataActionReadMetadata,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := azureErrorHint(tt.err, tt.dataAction)
+			if len(tt.want) == 0 {
+				if got != "" {
+					t.Errorf("azureErrorHint() = %q, want no hint", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, "\n\n") {
+				t.Errorf("azureErrorHint() = %q, want a hint separated by a blank line", got)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("azureErrorHint() = %q, want it to contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestHasAzureErrorCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		code string
+		want bool
+	}{
+		{
+			name: "error code",
+			body: `{"error":{"code":"Forbidden","message":"forbidden"}}`,
+			code: "Forbidden",
+			want: true,
+		},
+		{
+			name: "inner error code",
+			body: `{"error":{"code":"Forbidden","message":"Caller is not authorized","innererror":{"code":"ForbiddenByRbac"}}}`,
+			code: "ForbiddenByRbac",
+			want: true,
+		},
+		{
+			name: "nested inner error code",
+			body: `{"error":{"code":"Forbidden","innererror":{"code":"ForbiddenByPolicy","innererror":{"code":"AccessDenied"}}}}`,
+			code: "AccessDenied",
+			want: true,
+		},
+		{
+			name: "code in the message",
+			body: `{"error":{"code":"Forbidden","message":"The caller is not ForbiddenByFirewall","innererror":{"code":"ForbiddenByRbac"}}}`,
+			code: "ForbiddenByFirewall",
+		},
+		{
+			name: "prefix of the inner error code",
+			body: `{"error":{"code":"Conflict","innererror":{"code":"ObjectIsBeingDeleted"}}}`,
+			code: "ObjectIsBeing",
+		},
+		{
+			name: "suffix of the inner error code",
+			body: `{"error":{"code":"Forbidden","innererror":{"code":"ForbiddenByRbac"}}}`,
+			code: "ByRbac",
+		},
+		{
+			name: "not JSON",
+			body: `ForbiddenByRbac`,
+			code: "ForbiddenByRbac",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var respErr *azcore.ResponseError
+			if !errors.As(newTestResponseError(t, http.StatusForbidden, tt.body), &respErr) {
+				t.Fatal("not a response error")
+			}
+			if got := hasAzureErrorCode(respErr, tt.code); got != tt.want {
+				t.Errorf("hasAzureErrorCode(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAzureRequestDetails(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("failed to get: %w", newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"Caller is not authorized"}}`))
+	want := "\n\nVault name: vault-name\n" +
+		"Operation: GET /secrets/{name}\n" +
+		"Client request ID: 00000000-0000-0000-0000-000000000001\n" +
+		"Request ID: 00000000-0000-0000-0000-000000000002"
+	if got := azureRequestDetails(err); got != want {
+		t.Errorf("azureRequestDetails() = %q, want %q", got, want)
+	}
+
+	if got := azureRequestDetails(io.ErrUnexpectedEOF); got != "" {
+		t.Errorf("azureRequestDetails() = %q, want an empty string", got)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "404 Not Found",
+			err:  newTestResponseError(t, http.StatusNotFound, `{"error":{"code":"SecretNotFound","message":"not found"}}`),
+			want: true,
+		},
+		{
+			name: "no versions",
+			err:  fmt.Errorf("wrapped: %w", &secretNotFoundError{name: "secret-name", keyVaultID: testKeyVaultID}),
+			want: true,
+		},
+		{
+			name: "403 Forbidden",
+			err:  newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"forbidden"}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isNotFoundError(tt.err); got != tt.want {
+				t.Errorf("isNotFoundError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSecretUnavailableError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "404 Not Found",
+			err:  newTestResponseError(t, http.StatusNotFound, `{"error":{"code":"SecretNotFound","message":"not found"}}`),
+			want: true,
+		},
+		{
+			name: "no versions",
+			err:  &secretNotFoundError{name: "secret-name", keyVaultID: testKeyVaultID},
+			want: true,
+		},
+		{
+			name: "DNS error",
+			err:  fmt.Errorf("wrapped: %w", &net.DNSError{Err: "no such host", Name: "vault-name.vault.azure.net", IsNotFound: true}),
+			want: true,
+		},
+		{
+			name: "403 Forbidden",
+			err:  newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"forbidden"}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isSecretUnavailableError(tt.err); got != tt.want {
+				t.Errorf("isSecretUnavailableError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
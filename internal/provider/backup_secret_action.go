@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = (*BackupSecretAction)(nil)
+var _ action.ActionWithConfigure = (*BackupSecretAction)(nil)
+
+func NewBackupSecretAction() action.Action {
+	return &BackupSecretAction{}
+}
+
+// BackupSecretAction defines the action implementation.
+type BackupSecretAction struct {
+	client Client
+}
+
+type BackupSecretActionModel struct {
+	Name       types.String `tfsdk:"name"`
+	KeyVaultID types.String `tfsdk:"key_vault_id"`
+	OutputPath types.String `tfsdk:"output_path"`
+}
+
+func (a *BackupSecretAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_secret"
+}
+
+func (a *BackupSecretAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Backs up all versions of a Key Vault Secret and writes the protected backup blob to a local file. " +
+			"The blob can only be restored into a Key Vault in the same Azure subscription and geography.\n\n" +
+			"~> This action requires the `Microsoft.KeyVault/vaults/secrets/backup/action` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Secret to back up.",
+				Required:            true,
+			},
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault where the Secret is stored.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "The path of the file to write the backup blob to. Missing parent directories are created and an existing file is overwritten.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *BackupSecretAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = data.Client
+}
+
+func (a *BackupSecretAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model BackupSecretActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+	outputPath := model.OutputPath.ValueString()
+
+	backupResp, err := a.client.BackupSecret(ctx, model.KeyVaultID.ValueString(), name, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Back Up Secret",
+			"An unexpected error occurred while backing up a secret: "+err.Error()+azureErrorHint(err, dataActionBackup),
+		)
+		return
+	}
+
+	// The blob is encrypted by Key Vault, but make it readable only by the owner
+	// because it contains every version of the secret value.
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o700); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Write Backup",
+			fmt.Sprintf("An unexpected error occurred while creating the directory for %q: %s", outputPath, err),
+		)
+		return
+	}
+	if err := os.WriteFile(outputPath, backupResp.Value, 0o600); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Write Backup",
+			fmt.Sprintf("An unexpected error occurred while writing the backup of the secret %q to %q: %s", name, outputPath, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Wrote the secret backup", map[string]any{"path": outputPath, "bytes": len(backupResp.Value)})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Backed up the secret %q to %q", name, outputPath),
+	})
+}
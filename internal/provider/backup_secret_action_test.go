@@ -0,0 +1,80 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccBackupSecretAction_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	outputPath := filepath.Join(t.TempDir(), "secret.bak")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: backupSecretActionConfig(rn, outputPath),
+				Check:  testCheckFileNotEmpty(outputPath),
+			},
+		},
+	})
+}
+
+func testCheckFileNotEmpty(path string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.Size() == 0 {
+			return fmt.Errorf("%s is empty", path)
+		}
+		return nil
+	}
+}
+
+func backupSecretActionConfig(resourceSuffix, outputPath string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+}
+
+action "azurekv_backup_secret" "test" {
+  config {
+    name         = azurekv_secret.test.name
+    key_vault_id = azurekv_secret.test.key_vault_id
+    output_path  = %q
+  }
+}
+
+resource "terraform_data" "test" {
+  input = azurekv_secret.test.version
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.azurekv_backup_secret.test]
+    }
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix, outputPath)
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// The content types of the backing secret of a certificate, which are specified by the certificate policy.
+const (
+	certificateContentTypePKCS12 = "application/x-pkcs12"
+	certificateContentTypePEM    = "application/x-pem-file"
+)
+
+var errNoPrivateKey = errors.New("no private key is found in the certificate; the key of the certificate must be exportable")
+
+// certificatePrivateKeyPEM extracts the private key from the value of the backing secret of a certificate
+// and returns it as a PEM-encoded PKCS #8 private key.
+func certificatePrivateKeyPEM(contentType, value string) (string, error) {
+	var key any
+	switch contentType {
+	case certificateContentTypePKCS12:
+		pfx, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode the PFX: %w", err)
+		}
+		// Key Vault exports PFX files without a password
+		key, _, _, err = pkcs12.DecodeChain(pfx, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to parse the PFX: %w", err)
+		}
+	case certificateContentTypePEM:
+		var err error
+		key, err = pemPrivateKey([]byte(value))
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported content type %q; the content type of a certificate must be %q or %q", contentType, certificateContentTypePKCS12, certificateContentTypePEM)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// pemPrivateKey returns the first private key in the PEM-encoded data, which also contains the certificates.
+func pemPrivateKey(data []byte) (any, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errNoPrivateKey
+		}
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return parsePrivateKey(block.Bytes)
+		}
+	}
+}
+
+// parsePrivateKey parses a private key in PKCS #8, PKCS #1, or SEC 1 form.
+// The label of the PEM block is not trusted since some tools label PKCS #1 or SEC 1 keys "PRIVATE KEY".
+func parsePrivateKey(der []byte) (any, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse the private key: the key is not in PKCS #8, PKCS #1, or SEC 1 form")
+}
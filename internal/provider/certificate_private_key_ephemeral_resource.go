@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = (*CertificatePrivateKeyEphemeralResource)(nil)
+var _ ephemeral.EphemeralResourceWithConfigure = (*CertificatePrivateKeyEphemeralResource)(nil)
+
+func NewCertificatePrivateKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &CertificatePrivateKeyEphemeralResource{}
+}
+
+// CertificatePrivateKeyEphemeralResource defines the ephemeral resource implementation.
+type CertificatePrivateKeyEphemeralResource struct {
+	client Client
+}
+
+type CertificatePrivateKeyEphemeralResourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	KeyVaultID    types.String `tfsdk:"key_vault_id"`
+	Version       types.String `tfsdk:"version"`
+	PrivateKeyPEM types.String `tfsdk:"private_key_pem"`
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_private_key"
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the private key of a Key Vault Certificate in PEM form from its backing secret. " +
+			"The key is never stored in the plan or the state.\n\n" +
+			"~> The key of the certificate must be exportable, and this ephemeral resource requires the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Certificate.",
+				Required:            true,
+			},
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault where the Certificate is stored.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Specifies the version of the Key Vault Certificate. Defaults to the current version of the Key Vault Certificate.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"private_key_pem": schema.StringAttribute{
+				MarkdownDescription: "The private key of the Key Vault Certificate in PEM-encoded PKCS #8 form.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *CertificatePrivateKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model CertificatePrivateKeyEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+
+	// The backing secret of a certificate has the same name and version as the certificate
+	secretResp, err := r.client.GetSecret(ctx, model.KeyVaultID.ValueString(), name, model.Version.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Get Secret",
+			"An unexpected error occurred while getting the backing secret of a certificate: "+err.Error()+azureErrorHint(err, dataActionGetSecret),
+		)
+		return
+	}
+	if secretResp.KID == nil {
+		resp.Diagnostics.AddError(
+			"Not a Certificate",
+			fmt.Sprintf("The secret %q is not the backing secret of a certificate, so it has no private key.", name),
+		)
+		return
+	}
+
+	var contentType string
+	if secretResp.ContentType != nil {
+		contentType = *secretResp.ContentType
+	}
+	var value string
+	if secretResp.Value != nil {
+		value = *secretResp.Value
+	}
+	privateKeyPEM, err := certificatePrivateKeyPEM(contentType, value)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Extract Private Key",
+			fmt.Sprintf("An unexpected error occurred while extracting the private key of the certificate %q: %s", name, err),
+		)
+		return
+	}
+
+	model.Version = types.StringValue(secretResp.ID.Version())
+	model.PrivateKeyPEM = types.StringValue(privateKeyPEM)
+
+	tflog.Debug(ctx, "Retrieved the private key of the certificate", map[string]any{"name": name, "version": model.Version.ValueString()})
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestCertificatePrivateKeyPEM(t *testing.T) {
+	t.Parallel()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8DER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPKCS8DER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &ecKey.PublicKey, ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Windows and OpenSSL 3 export PFX files encrypted with AES-256 and SHA-256 by default
+	modernPFX, err := pkcs12.Modern.Encode(ecKey, cert, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyPFX, err := pkcs12.LegacyDES.Encode(ecKey, cert, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		value       string
+		want        []byte
+		wantErr     bool
+	}{
+		{
+			name:        "PEM with a PKCS #8 key",
+			contentType: certificateContentTypePEM,
+			value:       string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsaPKCS8DER})) + certPEM,
+			want:        rsaPKCS8DER,
+		},
+		{
+			name:        "PEM with a SEC 1 key after the certificate",
+			contentType: certificateContentTypePEM,
+			value:       certPEM + string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})),
+			want:        ecPKCS8DER,
+		},
+		{
+			name:        "PEM without a key",
+			contentType: certificateContentTypePEM,
+			value:       certPEM,
+			wantErr:     true,
+		},
+		{
+			name:        "PFX encrypted with AES",
+			contentType: certificateContentTypePKCS12,
+			value:       base64.StdEncoding.EncodeToString(modernPFX),
+			want:        ecPKCS8DER,
+		},
+		{
+			name:        "PFX encrypted with 3DES",
+			contentType: certificateContentTypePKCS12,
+			value:       base64.StdEncoding.EncodeToString(legacyPFX),
+			want:        ecPKCS8DER,
+		},
+		{
+			name:        "invalid PFX",
+			contentType: certificateContentTypePKCS12,
+			value:       base64.StdEncoding.EncodeToString([]byte("invalid")),
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "text/plain",
+			value:       "secret-value",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := certificatePrivateKeyPEM(tt.contentType, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("certificatePrivateKeyPEM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			block, _ := pem.Decode([]byte(got))
+			if block == nil || block.Type != "PRIVATE KEY" {
+				t.Fatalf("certificatePrivateKeyPEM() = %q, want a PKCS #8 PEM block", got)
+			}
+			if string(block.Bytes) != string(tt.want) {
+				t.Errorf("certificatePrivateKeyPEM() returned a different key")
+			}
+		})
+	}
+
+	if _, err := certificatePrivateKeyPEM(certificateContentTypePEM, certPEM); !errors.Is(err, errNoPrivateKey) {
+		t.Errorf("certificatePrivateKeyPEM() error = %v, want %v", err, errNoPrivateKey)
+	}
+}
@@ -2,51 +2,250 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"net/http"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/Azure/azure-sdk-for-go/sdk/tracing/azotel"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
 )
 
 type Client interface {
 	GetSubscriptionID() string
+	VaultURL(vaultName string) string
 	GetSecretProperties(ctx context.Context, keyVaultID, name string, version string, options *azsecrets.ListSecretPropertiesVersionsOptions) (*azsecrets.SecretProperties, error)
+	ListSecretPropertiesVersions(ctx context.Context, keyVaultID, name string) ([]*azsecrets.SecretProperties, error)
+	ListSecretProperties(ctx context.Context, keyVaultID string, options *ListSecretPropertiesOptions) iter.Seq2[*azsecrets.SecretProperties, error]
+	GetSecret(ctx context.Context, keyVaultID, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error)
 	SetSecret(ctx context.Context, keyVaultID, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error)
 	UpdateSecretProperties(ctx context.Context, keyVaultID, name string, version string, parameters azsecrets.UpdateSecretPropertiesParameters, options *azsecrets.UpdateSecretPropertiesOptions) (azsecrets.UpdateSecretPropertiesResponse, error)
 	DeleteSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error)
-	GetKeyVaultID(ctx context.Context, name string) (string, error)
+	GetDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.GetDeletedSecretOptions) (azsecrets.GetDeletedSecretResponse, error)
+	PurgeDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.PurgeDeletedSecretOptions) (azsecrets.PurgeDeletedSecretResponse, error)
+	RecoverDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.RecoverDeletedSecretOptions) (azsecrets.RecoverDeletedSecretResponse, error)
+	BackupSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.BackupSecretOptions) (azsecrets.BackupSecretResponse, error)
+	GetKeyVaultID(ctx context.Context, name string, options *GetKeyVaultIDOptions) (string, error)
+	ListKeyVaults(ctx context.Context, resourceGroupName string) ([]*armresources.GenericResourceExpanded, error)
+	CheckVaultHealth(ctx context.Context, keyVaultID string) VaultHealth
+	GetKeyVault(ctx context.Context, keyVaultID string) (*KeyVault, error)
+	ListDataPlaneRoleAssignments(ctx context.Context, keyVaultID string) (*DataPlaneRoleAssignments, error)
+	NotifyRotation(ctx context.Context, event RotationEvent) error
+	PublishEvent(ctx context.Context, eventType, keyVaultID, name, version string) error
+	// ReportMetrics logs the summary of the API calls made by the client and appends it to the metrics file if configured.
+	ReportMetrics() error
+}
+
+var errVaultHealthCheckSkipped = errors.New("skipped since the endpoint or the token check failed")
+
+// VaultHealth is the result of the checks of a key vault. A nil error means that the check passed.
+type VaultHealth struct {
+	// EndpointError is the error of connecting to the endpoint of the key vault.
+	EndpointError error
+	// TokenError is the error of acquiring an access token for Key Vault.
+	TokenError error
+	// ListError is the error of listing the secrets in the key vault.
+	ListError error
+}
+
+const (
+	defaultPollInterval = 5 * time.Second
+	// Deleting a secret usually completes in seconds, but it can take a few minutes.
+	deletedSecretTimeout = 5 * time.Minute
+	// keyVaultLookupTimeout bounds the key vault lookup shared by the callers of GetKeyVaultID,
+	// which is not canceled by any of them.
+	keyVaultLookupTimeout = 5 * time.Minute
+)
+
+// ClientOptions contains the optional settings of the client.
+type ClientOptions struct {
+	// DNSPropagationTimeout is how long requests to Key Vault are retried while the vault name can't be resolved or connected.
+	// Zero disables the retries.
+	DNSPropagationTimeout time.Duration
+	// RequestTimeout is the deadline of each request to ARM and Key Vault including its retries. Zero means no deadline.
+	RequestTimeout time.Duration
+	// VersionPropagationTimeout is how long SetSecret waits until the new version is listed by Key Vault.
+	// Zero disables the wait.
+	VersionPropagationTimeout time.Duration
+	// MaxRequestsPerSecond is the maximum number of requests sent to Key Vault per second. Zero means no limit.
+	MaxRequestsPerSecond int32
+	// MaxConcurrentRequests is the maximum number of in-flight requests to Key Vault. Zero means no limit.
+	MaxConcurrentRequests int32
+	// ProxyURL is the URL of the proxy for all requests. If empty, HTTPS_PROXY and NO_PROXY are used.
+	ProxyURL string
+	// CABundle is the PEM-encoded CA certificates trusted in addition to the system ones.
+	CABundle []byte
+	// MinTLSVersion is the minimum TLS version such as tls.VersionTLS12. Zero means the default of crypto/tls.
+	MinTLSVersion uint16
+	// MaxIdleConns is the maximum number of idle connections kept per host. Zero means the default of net/http.
+	MaxIdleConns int32
+	// IdleConnTimeout is how long an idle connection is kept before being closed. Zero means the default of net/http.
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives makes each request use a new connection,
+	// e.g. behind middleboxes that silently drop long-lived connections.
+	DisableKeepAlives bool
+	// InsecureSkipVerify disables the verification of the server certificates, e.g. for self-signed certificates of a local emulator.
+	InsecureSkipVerify bool
+	// VaultEndpoint is the URL of Key Vault, in which "{vault_name}" is replaced with the vault name, such as "https://{vault_name}.localhost:8443".
+	// If empty, "https://{vault_name}.vault.azure.net" is used.
+	VaultEndpoint string
+	// UserAgentSuffix is appended to the User-Agent header of the requests to ARM and Key Vault.
+	UserAgentSuffix string
+	// OTLPTracesEndpoint is the OTLP/HTTP endpoint to which the spans of the requests to ARM and Key Vault are exported.
+	// If empty, the endpoint environment variables of OpenTelemetry are used, and no spans are recorded if they are not set either.
+	OTLPTracesEndpoint string
+	// LoggedHeaders are the headers of the requests to ARM and Key Vault logged in addition to those allowed by the Azure SDK.
+	LoggedHeaders []string
+	// AuditLogFile is the path to which a JSON line is appended for each write operation. If empty, no audit log is written.
+	AuditLogFile string
+	// EventGridTopicEndpoint is the endpoint of the Event Grid custom topic to which an event is published for each secret written or deleted.
+	// If empty, no events are published.
+	EventGridTopicEndpoint string
+	// EventGridTopicKey is the access key of the Event Grid topic. If empty, the credential is used.
+	EventGridTopicKey string
+	// RotationWebhookURL is the URL to which a RotationEvent is posted when a secret is rotated. If empty, no notification is sent.
+	RotationWebhookURL string
+	// MetricsFile is the path to which the summary of the API calls is appended as a JSON line by ReportMetrics.
+	// If empty, the summary is only logged.
+	MetricsFile string
+	// Transport sends the requests to Azure instead of the HTTP client built from the options above,
+	// so that tests and downstream tooling can intercept the requests without calling Azure.
+	// The options of the HTTP client, such as ProxyURL, CABundle, and MaxIdleConns, are ignored if it is set.
+	Transport policy.Transporter
+	// DiscoverSubscriptionID makes NewClient discover the subscription ID if it is empty,
+	// from the default subscription of the Azure CLI or the only subscription accessible with the credential.
+	DiscoverSubscriptionID bool
+	// Credential authenticates the requests instead of DefaultAzureCredential.
+	Credential azcore.TokenCredential
 }
 
 type client struct {
-	cred           azcore.TokenCredential
+	// The credential and the resource client are constructed on first use,
+	// so that runs that don't call Azure, such as terraform validate, don't require credentials
+	credential     func() (azcore.TokenCredential, error)
+	resourceClient func() (*armresources.Client, error)
 	subscriptionID string
 	secretClients  map[string]*azsecrets.Client
-	resourceClient *armresources.Client
+	pollInterval   time.Duration
+	options        ClientOptions
+	rateLimiter    *rateLimitPolicy
+	concurrency    *concurrencyLimitPolicy
+	transport      policy.Transporter
+	// tracing is the no-op provider unless tracingEnabled is true
+	tracing        tracing.Provider
+	tracingEnabled bool
+	metrics        *apiMetrics
+	audit          *auditLog
+	webhook        *rotationWebhook
+	events         *eventGridPublisher
 	mutex          sync.Mutex
+	// The groups deduplicate concurrent client construction and key vault lookups for the same vault
+	secretClientGroup singleflight.Group
+	keyVaultIDGroup   singleflight.Group
+	// The options of the resource client are also used for the ARM APIs called without the SDKs
+	resourceClientOptions arm.ClientOptions
 }
 
 var _ Client = (*client)(nil)
 
-func NewClient(subscriptionID string) (Client, error) {
-	cred, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{})
-	if err != nil {
-		return nil, err
+func NewClient(subscriptionID string, options *ClientOptions) (Client, error) {
+	if options == nil {
+		options = &ClientOptions{}
 	}
 
-	resourceClient, err := armresources.NewClient(subscriptionID, cred, nil)
+	transport := options.Transport
+	if transport == nil {
+		httpClient, err := newHTTPClient(*options)
+		if err != nil {
+			return nil, err
+		}
+		transport = httpClient
+	}
+
+	tracerProvider, err := newTracerProvider(context.Background(), options.OTLPTracesEndpoint)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create the tracer provider: %w", err)
+	}
+	var tracingProvider tracing.Provider
+	if tracerProvider != nil {
+		tracingProvider = azotel.NewTracingProvider(tracerProvider, nil)
+	}
+
+	metrics := newAPIMetrics(options.MetricsFile)
+
+	credential := sync.OnceValues(func() (azcore.TokenCredential, error) {
+		if options.Credential != nil {
+			return options.Credential, nil
+		}
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: azcore.ClientOptions{
+				Transport: transport,
+			},
+		})
+	})
+
+	var resourceClientOptions arm.ClientOptions
+	resourceClientOptions.PerCallPolicies = append(resourceClientOptions.PerCallPolicies, &requestLogPolicy{}, &metricsCallPolicy{metrics: metrics})
+	resourceClientOptions.PerRetryPolicies = append(resourceClientOptions.PerRetryPolicies, &tryLogPolicy{}, &metricsTryPolicy{})
+	resourceClientOptions.Transport = transport
+	resourceClientOptions.TracingProvider = tracingProvider
+	resourceClientOptions.Logging.AllowedHeaders = options.LoggedHeaders
+	if options.UserAgentSuffix != "" {
+		resourceClientOptions.PerCallPolicies = append(resourceClientOptions.PerCallPolicies, &userAgentPolicy{suffix: options.UserAgentSuffix})
 	}
+	if options.RequestTimeout > 0 {
+		resourceClientOptions.PerCallPolicies = append(resourceClientOptions.PerCallPolicies, &requestTimeoutPolicy{timeout: options.RequestTimeout})
+	}
+
+	if subscriptionID == "" && options.DiscoverSubscriptionID {
+		var err error
+		subscriptionID, err = discoverSubscriptionID(context.Background(), credential, &resourceClientOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the subscription ID: %w", err)
+		}
+	}
+
+	notificationPipeline := newNotificationPipeline(&resourceClientOptions)
+
+	resourceClient := sync.OnceValues(func() (*armresources.Client, error) {
+		cred, err := credential()
+		if err != nil {
+			return nil, err
+		}
+
+		return armresources.NewClient(subscriptionID, cred, &resourceClientOptions)
+	})
 
 	return &client{
-		cred:           cred,
-		subscriptionID: subscriptionID,
-		resourceClient: resourceClient,
-		secretClients:  make(map[string]*azsecrets.Client),
+		credential:            credential,
+		resourceClient:        resourceClient,
+		subscriptionID:        subscriptionID,
+		secretClients:         make(map[string]*azsecrets.Client),
+		pollInterval:          defaultPollInterval,
+		options:               *options,
+		rateLimiter:           newRateLimitPolicy(options.MaxRequestsPerSecond),
+		concurrency:           newConcurrencyLimitPolicy(options.MaxConcurrentRequests),
+		transport:             transport,
+		tracing:               tracingProvider,
+		tracingEnabled:        tracerProvider != nil,
+		metrics:               metrics,
+		audit:                 newAuditLog(options.AuditLogFile, credential),
+		webhook:               newRotationWebhook(options.RotationWebhookURL, notificationPipeline),
+		events:                newEventGridPublisher(options.EventGridTopicEndpoint, options.EventGridTopicKey, notificationPipeline, credential),
+		resourceClientOptions: resourceClientOptions,
 	}, nil
 }
 
@@ -54,6 +253,28 @@ func (c *client) GetSubscriptionID() string {
 	return c.subscriptionID
 }
 
+// NotifyRotation posts the event to the rotation webhook if it is configured.
+func (c *client) NotifyRotation(ctx context.Context, event RotationEvent) error {
+	return c.webhook.notify(ctx, event)
+}
+
+// PublishEvent publishes the event of the secret to the Event Grid topic if it is configured.
+func (c *client) PublishEvent(ctx context.Context, eventType, keyVaultID, name, version string) error {
+	return c.events.publish(ctx, eventType, keyVaultID, name, version)
+}
+
+func (c *client) ReportMetrics() error {
+	if c.metrics == nil {
+		return nil
+	}
+	return c.metrics.report()
+}
+
+// GetSecretProperties returns the properties of the version of the secret, or those of the latest version if the version is empty.
+//
+// The versions are paged through because no other API returns the latest version with only the readMetadata permission:
+// the list-secrets API returns versionless IDs, and the list-versions API returns at most 25 versions per page in no particular order
+// without any sort or filter parameters. The paging stops as soon as the specified version is found.
 func (c *client) GetSecretProperties(ctx context.Context, keyVaultID, name string, version string, options *azsecrets.ListSecretPropertiesVersionsOptions) (*azsecrets.SecretProperties, error) {
 	secretClient, err := c.getSecretClient(keyVaultID)
 	if err != nil {
@@ -82,23 +303,213 @@ func (c *client) GetSecretProperties(ctx context.Context, keyVaultID, name strin
 	}
 
 	if latestSecretProperties == nil {
-		return nil, fmt.Errorf("the secret %q was not found in the key vault %q", name, keyVaultID)
+		return nil, &secretNotFoundError{name: name, keyVaultID: keyVaultID}
 	}
 
 	return latestSecretProperties, nil
 }
 
+func (c *client) ListSecretPropertiesVersions(ctx context.Context, keyVaultID, name string) ([]*azsecrets.SecretProperties, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*azsecrets.SecretProperties
+	pager := secretClient.NewListSecretPropertiesVersionsPager(name, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, page.Value...)
+	}
+
+	return versions, nil
+}
+
+// maxListPageSize is the maximum number of secrets that the list-secrets API returns in a page.
+const maxListPageSize = 25
+
+// ListSecretPropertiesOptions contains the optional parameters for ListSecretProperties.
+type ListSecretPropertiesOptions struct {
+	// PageSize is the number of secrets requested in a page, up to maxListPageSize. Zero means the default of Key Vault.
+	PageSize int32
+}
+
+// ListSecretProperties streams the properties of the latest versions of the secrets in the key vault.
+// The next page is requested only after the secrets in the current page are consumed,
+// so breaking the loop stops listing without reading the rest of the key vault.
+func (c *client) ListSecretProperties(ctx context.Context, keyVaultID string, options *ListSecretPropertiesOptions) iter.Seq2[*azsecrets.SecretProperties, error] {
+	return func(yield func(*azsecrets.SecretProperties, error) bool) {
+		secretClient, err := c.getSecretClient(keyVaultID)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		if options != nil && options.PageSize > 0 {
+			ctx = withListPageSize(ctx, options.PageSize)
+		}
+		pager := secretClient.NewListSecretPropertiesPager(nil)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, secret := range page.Value {
+				if !yield(secret, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *client) GetSecret(ctx context.Context, keyVaultID, name string, version string, options *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+
+	return secretClient.GetSecret(ctx, name, version, options)
+}
+
 func (c *client) SetSecret(ctx context.Context, keyVaultID, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error) {
 	secretClient, err := c.getSecretClient(keyVaultID)
 	if err != nil {
 		return azsecrets.SetSecretResponse{}, err
 	}
 
-	return secretClient.SetSecret(ctx, name, parameters, options)
+	resp, err := secretClient.SetSecret(ctx, name, parameters, options)
+	if err != nil {
+		return resp, err
+	}
+	c.audit.record(ctx, auditOperationSet, keyVaultID, name, resp.ID.Version())
+	if c.options.VersionPropagationTimeout <= 0 {
+		return resp, nil
+	}
+
+	c.waitForVersionVisible(ctx, keyVaultID, name, resp.ID.Version())
+	return resp, nil
+}
+
+// waitForVersionVisible polls until the version is listed, because Key Vault is eventually consistent
+// and data sources or replicas reading the secret right after SetSecret may observe the previous version.
+// The secret has already been set, so it only logs a warning if the version doesn't become visible in time.
+func (c *client) waitForVersionVisible(ctx context.Context, keyVaultID, name, version string) {
+	deadline := time.Now().Add(c.options.VersionPropagationTimeout)
+	for {
+		versions, err := c.ListSecretPropertiesVersions(ctx, keyVaultID, name)
+		if err == nil && slices.ContainsFunc(versions, func(props *azsecrets.SecretProperties) bool {
+			return props.ID.Version() == version
+		}) {
+			return
+		}
+		if err != nil && !isNotFoundError(err) {
+			tflog.Warn(ctx, "Failed to confirm that the new version of the secret is visible", map[string]any{"error": err.Error()})
+			return
+		}
+		if time.Now().Add(c.pollInterval).After(deadline) {
+			tflog.Warn(ctx, "The new version of the secret is not visible yet", map[string]any{"version": version})
+			return
+		}
+
+		tflog.Debug(ctx, "Waiting for the new version of the secret to become visible", map[string]any{"version": version})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// GetKeyVaultIDOptions contains the optional parameters for GetKeyVaultID.
+type GetKeyVaultIDOptions struct {
+	// SearchAllSubscriptions makes GetKeyVaultID look up the key vault also in the other subscriptions accessible with the credential
+	// if it isn't in the subscription of the client. This is only for import, where the vault name in the ID doesn't tell
+	// which subscription the key vault belongs to.
+	SearchAllSubscriptions bool
+}
+
+func (c *client) GetKeyVaultID(ctx context.Context, vaultName string, options *GetKeyVaultIDOptions) (string, error) {
+	searchAllSubscriptions := options != nil && options.SearchAllSubscriptions
+	// Key vault names are case-insensitive
+	key := strings.ToLower(vaultName)
+	if searchAllSubscriptions {
+		key += "\x00all"
+	}
+	// The lookup is shared with the other callers, so the cancellation of the first caller must not fail them,
+	// and each caller stops waiting when its own context is done.
+	// The lookup has its own deadline instead so that a hung request doesn't block the later callers forever.
+	ch := c.keyVaultIDGroup.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), keyVaultLookupTimeout)
+		defer cancel()
+		return c.findKeyVaultID(ctx, vaultName, searchAllSubscriptions)
+	})
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	}
+}
+
+func (c *client) findKeyVaultID(ctx context.Context, vaultName string, searchAllSubscriptions bool) (string, error) {
+	notFoundErr := fmt.Errorf("the key vault %q not found; make sure that the key vault name is correct and that you have the \"Microsoft.KeyVault/vaults/read\" permission", vaultName)
+
+	if c.subscriptionID != "" || !searchAllSubscriptions {
+		resourceClient, err := c.resourceClient()
+		if err != nil {
+			return "", err
+		}
+
+		id, err := findKeyVaultIDInSubscription(ctx, resourceClient, vaultName)
+		if err != nil || id != "" {
+			return id, err
+		}
+		if !searchAllSubscriptions {
+			return "", notFoundErr
+		}
+	}
+
+	cred, err := c.credential()
+	if err != nil {
+		return "", err
+	}
+	subscriptionIDs, err := listSubscriptionIDs(ctx, cred, &c.resourceClientOptions)
+	if err != nil {
+		return "", fmt.Errorf("%w (failed to list the other subscriptions: %w)", notFoundErr, err)
+	}
+	for _, subscriptionID := range subscriptionIDs {
+		if strings.EqualFold(subscriptionID, c.subscriptionID) {
+			continue
+		}
+
+		resourceClient, err := armresources.NewClient(subscriptionID, cred, &c.resourceClientOptions)
+		if err != nil {
+			return "", err
+		}
+		id, err := findKeyVaultIDInSubscription(ctx, resourceClient, vaultName)
+		if err != nil || id != "" {
+			return id, err
+		}
+	}
+
+	return "", fmt.Errorf("the key vault %q not found in any subscriptions accessible with the credential; make sure that the key vault name is correct and that you have the \"Microsoft.KeyVault/vaults/read\" permission", vaultName)
 }
 
-func (c *client) GetKeyVaultID(ctx context.Context, vaultName string) (string, error) {
-	pager := c.resourceClient.NewListPager(&armresources.ClientListOptions{
+// findKeyVaultIDInSubscription returns the ID of the key vault in the subscription of the resource client,
+// or an empty string if not found.
+func findKeyVaultIDInSubscription(ctx context.Context, resourceClient *armresources.Client, vaultName string) (string, error) {
+	pager := resourceClient.NewListPager(&armresources.ClientListOptions{
 		Filter: to.Ptr(fmt.Sprintf("resourceType eq 'Microsoft.KeyVault/vaults' and name eq '%s'", vaultName)),
 	})
 	for pager.More() {
@@ -112,7 +523,91 @@ func (c *client) GetKeyVaultID(ctx context.Context, vaultName string) (string, e
 		}
 	}
 
-	return "", fmt.Errorf("the key vault %q not found; make sure that the key vault name is correct and that you have the \"Microsoft.KeyVault/vaults/read\" permission", vaultName)
+	return "", nil
+}
+
+func (c *client) ListKeyVaults(ctx context.Context, resourceGroupName string) ([]*armresources.GenericResourceExpanded, error) {
+	resourceClient, err := c.resourceClient()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := to.Ptr("resourceType eq 'Microsoft.KeyVault/vaults'")
+
+	var keyVaults []*armresources.GenericResourceExpanded
+	if resourceGroupName != "" {
+		pager := resourceClient.NewListByResourceGroupPager(resourceGroupName, &armresources.ClientListByResourceGroupOptions{
+			Filter: filter,
+		})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			keyVaults = append(keyVaults, page.Value...)
+		}
+
+		return keyVaults, nil
+	}
+
+	pager := resourceClient.NewListPager(&armresources.ClientListOptions{
+		Filter: filter,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		keyVaults = append(keyVaults, page.Value...)
+	}
+
+	return keyVaults, nil
+}
+
+// CheckVaultHealth checks that the endpoint of the key vault is reachable, that an access token can be acquired,
+// and that the secrets can be listed. The listing is skipped if either of the other checks fails.
+func (c *client) CheckVaultHealth(ctx context.Context, keyVaultID string) VaultHealth {
+	var health VaultHealth
+
+	vaultName, err := extractVaultName(keyVaultID)
+	if err != nil {
+		health.EndpointError = err
+		health.ListError = errVaultHealthCheckSkipped
+		return health
+	}
+
+	// Any response, even 401 Unauthorized, means that the endpoint is reachable
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.VaultURL(vaultName), nil)
+	if err == nil {
+		var resp *http.Response
+		resp, err = c.transport.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	health.EndpointError = err
+
+	cred, err := c.credential()
+	if err == nil {
+		_, err = cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://vault.azure.net/.default"}})
+	}
+	health.TokenError = err
+
+	if health.EndpointError != nil || health.TokenError != nil {
+		health.ListError = errVaultHealthCheckSkipped
+		return health
+	}
+
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err == nil {
+		// The first page is enough to check the permission
+		_, err = secretClient.NewListSecretPropertiesPager(nil).NextPage(ctx)
+	}
+	health.ListError = err
+
+	return health
 }
 
 func (c *client) UpdateSecretProperties(ctx context.Context, keyVaultID, name string, version string, parameters azsecrets.UpdateSecretPropertiesParameters, options *azsecrets.UpdateSecretPropertiesOptions) (azsecrets.UpdateSecretPropertiesResponse, error) {
@@ -121,7 +616,12 @@ func (c *client) UpdateSecretProperties(ctx context.Context, keyVaultID, name st
 		return azsecrets.UpdateSecretPropertiesResponse{}, err
 	}
 
-	return secretClient.UpdateSecretProperties(ctx, name, version, parameters, options)
+	resp, err := secretClient.UpdateSecretProperties(ctx, name, version, parameters, options)
+	if err != nil {
+		return resp, err
+	}
+	c.audit.record(ctx, auditOperationUpdate, keyVaultID, name, resp.ID.Version())
+	return resp, nil
 }
 
 func (c *client) DeleteSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error) {
@@ -130,7 +630,114 @@ func (c *client) DeleteSecret(ctx context.Context, keyVaultID, name string, opti
 		return azsecrets.DeleteSecretResponse{}, err
 	}
 
-	return secretClient.DeleteSecret(ctx, name, options)
+	resp, err := secretClient.DeleteSecret(ctx, name, options)
+	if err != nil {
+		return resp, err
+	}
+	c.audit.record(ctx, auditOperationDelete, keyVaultID, name, "")
+	return resp, nil
+}
+
+func (c *client) GetDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.GetDeletedSecretOptions) (azsecrets.GetDeletedSecretResponse, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return azsecrets.GetDeletedSecretResponse{}, err
+	}
+
+	return secretClient.GetDeletedSecret(ctx, name, options)
+}
+
+// PurgeDeletedSecret waits until the deletion of the secret completes and then purges it.
+func (c *client) PurgeDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.PurgeDeletedSecretOptions) (azsecrets.PurgeDeletedSecretResponse, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return azsecrets.PurgeDeletedSecretResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deletedSecretTimeout)
+	defer cancel()
+
+	for {
+		_, err := secretClient.GetDeletedSecret(ctx, name, nil)
+		if err == nil {
+			break
+		}
+		if !isNotFoundError(err) {
+			return azsecrets.PurgeDeletedSecretResponse{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return azsecrets.PurgeDeletedSecretResponse{}, fmt.Errorf("timed out waiting for the secret %q to be deleted: %w", name, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+
+	resp, err := secretClient.PurgeDeletedSecret(ctx, name, options)
+	if err != nil {
+		return resp, err
+	}
+	c.audit.record(ctx, auditOperationPurge, keyVaultID, name, "")
+	return resp, nil
+}
+
+// RecoverDeletedSecret waits until the deletion of the secret completes, recovers it, and then waits until the recovery completes.
+func (c *client) RecoverDeletedSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.RecoverDeletedSecretOptions) (azsecrets.RecoverDeletedSecretResponse, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return azsecrets.RecoverDeletedSecretResponse{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deletedSecretTimeout)
+	defer cancel()
+
+	for {
+		_, err := secretClient.GetDeletedSecret(ctx, name, nil)
+		if err == nil {
+			break
+		}
+		if !isNotFoundError(err) {
+			return azsecrets.RecoverDeletedSecretResponse{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return azsecrets.RecoverDeletedSecretResponse{}, fmt.Errorf("timed out waiting for the secret %q to be deleted: %w", name, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+
+	resp, err := secretClient.RecoverDeletedSecret(ctx, name, options)
+	if err != nil {
+		return resp, err
+	}
+	c.audit.record(ctx, auditOperationRecover, keyVaultID, name, resp.ID.Version())
+
+	// The recovery is asynchronous, and the secret can't be set until it completes
+	for {
+		_, err := c.GetSecretProperties(ctx, keyVaultID, name, "", nil)
+		if err == nil {
+			return resp, nil
+		}
+		if !isNotFoundError(err) {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp, fmt.Errorf("timed out waiting for the secret %q to be recovered: %w", name, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+func (c *client) BackupSecret(ctx context.Context, keyVaultID, name string, options *azsecrets.BackupSecretOptions) (azsecrets.BackupSecretResponse, error) {
+	secretClient, err := c.getSecretClient(keyVaultID)
+	if err != nil {
+		return azsecrets.BackupSecretResponse{}, err
+	}
+
+	return secretClient.BackupSecret(ctx, name, options)
 }
 
 func (c *client) getSecretClient(keyVaultID string) (*azsecrets.Client, error) {
@@ -139,18 +746,185 @@ func (c *client) getSecretClient(keyVaultID string) (*azsecrets.Client, error) {
 		return nil, err
 	}
 
+	// Key vault names are case-insensitive, so the IDs differing only in case share the client
+	key := strings.ToLower(vaultName)
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	if secretClient, ok := c.secretClients[vaultName]; ok {
+	secretClient, ok := c.secretClients[key]
+	c.mutex.Unlock()
+	if ok {
 		return secretClient, nil
 	}
 
-	secretClient, err := azsecrets.NewClient("https://"+vaultName+".vault.azure.net", c.cred, nil)
+	v, err, _ := c.secretClientGroup.Do(key, func() (any, error) {
+		return c.newSecretClient(vaultName)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*azsecrets.Client), nil
+}
+
+// VaultURL returns the URL of the key vault, which is replaced with VaultEndpoint if it is set.
+func (c *client) VaultURL(vaultName string) string {
+	if c.options.VaultEndpoint != "" {
+		return strings.ReplaceAll(c.options.VaultEndpoint, "{vault_name}", vaultName)
+	}
+	return defaultVaultURL(vaultName)
+}
+
+// defaultVaultURL returns the URL of the key vault without VaultEndpoint.
+func defaultVaultURL(vaultName string) string {
+	return "https://" + vaultName + ".vault.azure.net"
+}
+
+func (c *client) newSecretClient(vaultName string) (*azsecrets.Client, error) {
+	var clientOptions azsecrets.ClientOptions
+	clientOptions.Logging.AllowedHeaders = c.options.LoggedHeaders
+	// The log fields come first so that the logs of the other policies have them
+	vault, err := newVaultRequest(vaultName, c.VaultURL(vaultName))
 	if err != nil {
 		return nil, err
 	}
-	c.secretClients[vaultName] = secretClient
+	clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &requestLogPolicy{vault: vault}, &listPageSizePolicy{})
+	clientOptions.PerRetryPolicies = append(clientOptions.PerRetryPolicies, &tryLogPolicy{})
+	// The metrics policy comes first so that the latencies include the retries of the other policies
+	clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &metricsCallPolicy{metrics: c.metrics})
+	clientOptions.PerRetryPolicies = append(clientOptions.PerRetryPolicies, &metricsTryPolicy{})
+	if c.transport != nil {
+		clientOptions.Transport = c.transport
+	}
+	// The limiter is shared by all the vaults and is also applied to retries.
+	// It is always added because it holds the requests throttled with Retry-After even without the rate limit.
+	clientOptions.PerRetryPolicies = append(clientOptions.PerRetryPolicies, c.rateLimiter)
+	if c.concurrency != nil {
+		// The slot is acquired after the rate limiter so that requests waiting for the limiter don't occupy slots
+		clientOptions.PerRetryPolicies = append(clientOptions.PerRetryPolicies, c.concurrency)
+	}
+	if c.options.UserAgentSuffix != "" {
+		clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &userAgentPolicy{suffix: c.options.UserAgentSuffix})
+	}
+	if c.options.DNSPropagationTimeout > 0 {
+		clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &dnsRetryPolicy{
+			timeout:  c.options.DNSPropagationTimeout,
+			interval: c.pollInterval,
+		})
+	}
+	if c.options.RequestTimeout > 0 {
+		// The deadline comes after the DNS retries so that each retry has its own deadline
+		clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &requestTimeoutPolicy{timeout: c.options.RequestTimeout})
+	}
+	if c.tracingEnabled {
+		clientOptions.TracingProvider = c.tracing
+		clientOptions.PerCallPolicies = append(clientOptions.PerCallPolicies, &secretSpanAttributesPolicy{
+			tracer: c.tracing.NewTracer(tracerScopeName, ""),
+		})
+	}
+
+	cred, err := c.credential()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.options.VaultEndpoint != "" {
+		// The resource in the challenge of an emulator doesn't match the domain of Key Vault
+		clientOptions.DisableChallengeResourceVerification = true
+	}
+
+	secretClient, err := azsecrets.NewClient(c.VaultURL(vaultName), cred, &clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.secretClients[strings.ToLower(vaultName)] = secretClient
+	c.mutex.Unlock()
 
 	return secretClient, nil
 }
+
+// retryOnForbiddenByRBAC calls f until it succeeds, it fails with an error other than ForbiddenByRbac, or the timeout elapses.
+// A new role assignment takes a few minutes to propagate, during which requests fail with ForbiddenByRbac.
+func retryOnForbiddenByRBAC(ctx context.Context, timeout, interval time.Duration, f func() error) error {
+	return retryOnError(ctx, timeout, interval, isForbiddenByRBACError, "Retrying because the role assignments seem not to be propagated yet", f)
+}
+
+// retryWhileBeingDeleted calls f until it succeeds, it fails with an error other than ObjectIsBeingDeleted, or the timeout elapses.
+// Deleting or purging a secret takes a while, during which a secret with the same name can't be created.
+func retryWhileBeingDeleted(ctx context.Context, timeout, interval time.Duration, f func() error) error {
+	return retryOnError(ctx, timeout, interval, isBeingDeletedError, "Retrying because the secret with the same name is being deleted", f)
+}
+
+// retryOnError calls f until it succeeds, it fails with an error for which retryable returns false, or the timeout elapses.
+func retryOnError(ctx context.Context, timeout, interval time.Duration, retryable func(error) bool, message string, f func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := f()
+		if err == nil || !retryable(err) || time.Now().Add(interval).After(deadline) {
+			return err
+		}
+
+		tflog.Debug(ctx, message, map[string]any{"error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+	}
+}
+
+// isForbiddenByRBACError reports whether the error is caused by the lack of role assignments.
+// Key Vault returns the code ForbiddenByRbac as the inner error of Forbidden.
+func isForbiddenByRBACError(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden && hasAzureErrorCode(respErr, "ForbiddenByRbac")
+}
+
+// setSecretRecoveringDeleted sets the secret, and if a secret with the same name is deleted but recoverable,
+// recovers it and sets the secret again as a new version of the recovered one.
+// A secret being deleted or purged is not recovered, so callers should retry the error with retryWhileBeingDeleted.
+func setSecretRecoveringDeleted(ctx context.Context, c Client, keyVaultID, name string, parameters azsecrets.SetSecretParameters, options *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error) {
+	resp, err := c.SetSecret(ctx, keyVaultID, name, parameters, options)
+	if err == nil || !isSoftDeletedConflictError(err) {
+		return resp, err
+	}
+
+	tflog.Info(ctx, "Recovering the deleted secret with the same name", map[string]any{"key_vault_id": keyVaultID, "name": name})
+	if _, err := c.RecoverDeletedSecret(ctx, keyVaultID, name, nil); err != nil {
+		return resp, fmt.Errorf("failed to recover the deleted secret %q: %w", name, err)
+	}
+
+	return c.SetSecret(ctx, keyVaultID, name, parameters, options)
+}
+
+// isBeingDeletedError reports whether the error is caused by a secret with the same name that is being deleted or purged.
+func isBeingDeletedError(err error) bool {
+	var respErr *azcore.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict && hasAzureErrorCode(respErr, "ObjectIsBeingDeleted")
+}
+
+// isSoftDeletedConflictError reports whether the error is caused by a secret with the same name that is deleted but recoverable.
+// A secret being deleted isn't included, since it may be being purged and can't be recovered then.
+func isSoftDeletedConflictError(err error) bool {
+	var respErr *azcore.ResponseError
+	// Key Vault returns the code as the inner error of Conflict
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict && hasAzureErrorCode(respErr, "ObjectIsDeletedButRecoverable")
+}
+
+// secretNotFoundError is returned by GetSecretProperties if the secret has no versions.
+type secretNotFoundError struct {
+	name       string
+	keyVaultID string
+}
+
+func (e *secretNotFoundError) Error() string {
+	return fmt.Sprintf("the secret %q was not found in the key vault %q", e.name, e.keyVaultID)
+}
+
+// isNotFoundError returns whether the error means that the resource doesn't exist,
+// including the secret that exists but has no versions.
+func isNotFoundError(err error) bool {
+	var respErr *azcore.ResponseError
+	var notFoundErr *secretNotFoundError
+	return (errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound) || errors.As(err, &notFoundErr)
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// staticTokenCredential always returns the same token, e.g. for a local emulator that accepts any token.
+type staticTokenCredential struct {
+	token string
+}
+
+var _ azcore.TokenCredential = (*staticTokenCredential)(nil)
+
+func (c *staticTokenCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	// The expiration is unknown, so it is set far enough in the future that the token is never refreshed
+	return azcore.AccessToken{Token: c.token, ExpiresOn: time.Now().Add(24 * time.Hour)}, nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// notificationTimeout is the deadline of each notification to an endpoint outside Key Vault including its retries,
+// such as the rotation webhook and the Event Grid topic, so that a slow endpoint doesn't hold the apply.
+const notificationTimeout = 30 * time.Second
+
+// newNotificationPipeline returns the pipeline for the notifications, which has the same policies as the ARM requests,
+// such as the retries and the logging, but no authentication.
+func newNotificationPipeline(options *arm.ClientOptions) runtime.Pipeline {
+	return runtime.NewPipeline("azurekv", "v0.0.0", runtime.PipelineOptions{}, &options.ClientOptions)
+}
+
+// postJSON posts the body as JSON with the header through the pipeline,
+// and returns an error if the endpoint doesn't respond with a 2xx status.
+func postJSON(ctx context.Context, pipeline runtime.Pipeline, url string, header http.Header, body any) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	req, err := runtime.NewRequest(ctx, http.MethodPost, url)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Raw().Header[key] = values
+	}
+	if err := runtime.MarshalAsJSON(req, body); err != nil {
+		return err
+	}
+
+	resp, err := pipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return runtime.NewResponseError(resp)
+	}
+	runtime.Drain(resp)
+	return nil
+}
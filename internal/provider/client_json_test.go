@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+func TestPostJSON(t *testing.T) {
+	t.Parallel()
+
+	var tries int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tries++
+		if v := r.Header.Get("X-Test"); v != "value" {
+			t.Errorf("got X-Test %q, want %q", v, "value")
+		}
+		var got map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode the body: %v", err)
+		}
+		if got["key"] != "value" {
+			t.Errorf("got %v, want the body to be posted on every try", got)
+		}
+		if tries == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	pipeline := newNotificationPipeline(&arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Retry:     policy.RetryOptions{RetryDelay: time.Millisecond},
+			Transport: server.Client(),
+		},
+	})
+	err := postJSON(context.Background(), pipeline, server.URL, http.Header{"X-Test": []string{"value"}}, map[string]string{"key": "value"})
+	if err != nil {
+		t.Fatalf("postJSON() error = %v", err)
+	}
+	if tries != 2 {
+		t.Errorf("got %d tries, want 2", tries)
+	}
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const keyVaultsAPIVersion = "2023-07-01"
+
+// Key Vault omits the properties with the default values from the response.
+const (
+	defaultSoftDeleteRetentionDays = 90
+	defaultPublicNetworkAccess     = "Enabled"
+	defaultNetworkACLsAction       = "Allow"
+	defaultNetworkACLsBypass       = "AzureServices"
+)
+
+// KeyVault is the result of GetKeyVault.
+type KeyVault struct {
+	ID                       string
+	Name                     string
+	Location                 string
+	VaultURI                 string
+	SoftDeleteRetentionDays  int32
+	PurgeProtectionEnabled   bool
+	RBACAuthorizationEnabled bool
+	PublicNetworkAccess      string
+	NetworkACLs              KeyVaultNetworkACLs
+}
+
+// KeyVaultNetworkACLs summarizes the network rules of a key vault.
+type KeyVaultNetworkACLs struct {
+	DefaultAction           string
+	Bypass                  string
+	IPRules                 []string
+	VirtualNetworkSubnetIDs []string
+}
+
+// GetKeyVault returns the properties of the key vault, which requires the "Microsoft.KeyVault/vaults/read" permission.
+func (c *client) GetKeyVault(ctx context.Context, keyVaultID string) (*KeyVault, error) {
+	cred, err := c.credential()
+	if err != nil {
+		return nil, err
+	}
+	return getKeyVault(ctx, cred, &c.resourceClientOptions, keyVaultID)
+}
+
+// getKeyVault gets the key vault in the same way as Vaults.Get of armkeyvault.
+// The API is called directly since the SDK of the Key Vault management API is not worth another dependency.
+func getKeyVault(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, keyVaultID string) (*KeyVault, error) {
+	armClient, err := arm.NewClient("azurekv", "v0.0.0", cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("api-version", keyVaultsAPIVersion)
+	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(armClient.Endpoint(), keyVaultID)+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := armClient.Pipeline().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
+	}
+
+	var vault struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		Location   string `json:"location"`
+		Properties struct {
+			VaultURI                  string `json:"vaultUri"`
+			SoftDeleteRetentionInDays *int32 `json:"softDeleteRetentionInDays"`
+			EnablePurgeProtection     *bool  `json:"enablePurgeProtection"`
+			EnableRbacAuthorization   *bool  `json:"enableRbacAuthorization"`
+			PublicNetworkAccess       string `json:"publicNetworkAccess"`
+			NetworkACLs               *struct {
+				DefaultAction string `json:"defaultAction"`
+				Bypass        string `json:"bypass"`
+				IPRules       []struct {
+					Value string `json:"value"`
+				} `json:"ipRules"`
+				VirtualNetworkRules []struct {
+					ID string `json:"id"`
+				} `json:"virtualNetworkRules"`
+			} `json:"networkAcls"`
+		} `json:"properties"`
+	}
+	if err := runtime.UnmarshalAsJSON(resp, &vault); err != nil {
+		return nil, err
+	}
+
+	kv := &KeyVault{
+		ID:                       vault.ID,
+		Name:                     vault.Name,
+		Location:                 vault.Location,
+		VaultURI:                 vault.Properties.VaultURI,
+		SoftDeleteRetentionDays:  defaultSoftDeleteRetentionDays,
+		PurgeProtectionEnabled:   vault.Properties.EnablePurgeProtection != nil && *vault.Properties.EnablePurgeProtection,
+		RBACAuthorizationEnabled: vault.Properties.EnableRbacAuthorization != nil && *vault.Properties.EnableRbacAuthorization,
+		PublicNetworkAccess:      defaultPublicNetworkAccess,
+		NetworkACLs: KeyVaultNetworkACLs{
+			DefaultAction:           defaultNetworkACLsAction,
+			Bypass:                  defaultNetworkACLsBypass,
+			IPRules:                 []string{},
+			VirtualNetworkSubnetIDs: []string{},
+		},
+	}
+	if vault.Properties.SoftDeleteRetentionInDays != nil {
+		kv.SoftDeleteRetentionDays = *vault.Properties.SoftDeleteRetentionInDays
+	}
+	if vault.Properties.PublicNetworkAccess != "" {
+		kv.PublicNetworkAccess = vault.Properties.PublicNetworkAccess
+	}
+	if acls := vault.Properties.NetworkACLs; acls != nil {
+		if acls.DefaultAction != "" {
+			kv.NetworkACLs.DefaultAction = acls.DefaultAction
+		}
+		if acls.Bypass != "" {
+			kv.NetworkACLs.Bypass = acls.Bypass
+		}
+		for _, r := range acls.IPRules {
+			kv.NetworkACLs.IPRules = append(kv.NetworkACLs.IPRules, r.Value)
+		}
+		for _, r := range acls.VirtualNetworkRules {
+			kv.NetworkACLs.VirtualNetworkSubnetIDs = append(kv.NetworkACLs.VirtualNetworkSubnetIDs, r.ID)
+		}
+	}
+
+	return kv, nil
+}
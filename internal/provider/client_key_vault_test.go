@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClientGetKeyVault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want *KeyVault
+	}{
+		{
+			name: "all the properties",
+			body: `{"id":"` + testKeyVaultID + `","name":"` + vaultName + `","location":"japaneast","properties":{` +
+				`"vaultUri":"` + testVaultURL + `/","softDeleteRetentionInDays":7,"enablePurgeProtection":true,"enableRbacAuthorization":true,"publicNetworkAccess":"Disabled",` +
+				`"networkAcls":{"defaultAction":"Deny","bypass":"None","ipRules":[{"value":"203.0.113.0/24"}],"virtualNetworkRules":[{"id":"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"}]}}}`,
+			want: &KeyVault{
+				ID:                       testKeyVaultID,
+				Name:                     vaultName,
+				Location:                 "japaneast",
+				VaultURI:                 testVaultURL + "/",
+				SoftDeleteRetentionDays:  7,
+				PurgeProtectionEnabled:   true,
+				RBACAuthorizationEnabled: true,
+				PublicNetworkAccess:      "Disabled",
+				NetworkACLs: KeyVaultNetworkACLs{
+					DefaultAction:           "Deny",
+					Bypass:                  "None",
+					IPRules:                 []string{"203.0.113.0/24"},
+					VirtualNetworkSubnetIDs: []string{"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/subnet"},
+				},
+			},
+		},
+		{
+			name: "default properties omitted",
+			body: `{"id":"` + testKeyVaultID + `","name":"` + vaultName + `","location":"japaneast","properties":{"vaultUri":"` + testVaultURL + `/"}}`,
+			want: &KeyVault{
+				ID:                      testKeyVaultID,
+				Name:                    vaultName,
+				Location:                "japaneast",
+				VaultURI:                testVaultURL + "/",
+				SoftDeleteRetentionDays: 90,
+				PublicNetworkAccess:     "Enabled",
+				NetworkACLs: KeyVaultNetworkACLs{
+					DefaultAction:           "Allow",
+					Bypass:                  "AzureServices",
+					IPRules:                 []string{},
+					VirtualNetworkSubnetIDs: []string{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewClient("sub", &ClientOptions{
+				Credential: &staticTokenCredential{token: testJWT(`{}`)},
+				Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != testKeyVaultID {
+						t.Errorf("got a request to %s, want %s", req.URL.Path, testKeyVaultID)
+					}
+					if got := req.URL.Query().Get("api-version"); got != keyVaultsAPIVersion {
+						t.Errorf("got api-version %q, want %q", got, keyVaultsAPIVersion)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+						Request:    req,
+					}, nil
+				}),
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			got, err := c.GetKeyVault(t.Context(), testKeyVaultID)
+			if err != nil {
+				t.Fatalf("GetKeyVault() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetKeyVault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientGetKeyVaultNotFound(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient("sub", &ClientOptions{
+		Credential: &staticTokenCredential{token: testJWT(`{}`)},
+		Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"ResourceNotFound","message":"not found"}}`)),
+				Request:    req,
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := c.GetKeyVault(t.Context(), testKeyVaultID); !isNotFoundError(err) {
+		t.Errorf("GetKeyVault() error = %v, want a not-found error", err)
+	}
+}
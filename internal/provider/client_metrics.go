@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// metricsInstances numbers the metrics of the clients in the process, so that the lines of the clients can be told apart.
+var metricsInstances atomic.Int64
+
+// apiMetrics aggregates the API calls per operation, so that users can see why a run took long,
+// e.g. how many calls were made and how many of them were throttled.
+type apiMetrics struct {
+	file       string
+	instance   int64
+	mutex      sync.Mutex
+	operations map[string]*operationMetrics
+}
+
+// operationMetrics is the summary of the calls of an operation.
+// The latencies include the retries, and Retries is the number of the tries except the first one of each call.
+type operationMetrics struct {
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	Retries      int     `json:"retries"`
+	Throttled    int     `json:"throttled"`
+	TotalSeconds float64 `json:"total_seconds"`
+	MaxSeconds   float64 `json:"max_seconds"`
+}
+
+// metricsReport is the line appended to the metrics file. Terraform runs the provider for each of plan and apply,
+// so the lines are tagged with the process and the instance of the metrics in the process.
+type metricsReport struct {
+	Time       time.Time                   `json:"time"`
+	PID        int                         `json:"pid"`
+	Instance   int64                       `json:"instance"`
+	Operations map[string]operationMetrics `json:"operations"`
+}
+
+// newAPIMetrics returns the metrics reported on exit. The summary is also appended to the file as a JSON line unless file is empty.
+func newAPIMetrics(file string) *apiMetrics {
+	return &apiMetrics{
+		file:       file,
+		instance:   metricsInstances.Add(1),
+		operations: make(map[string]*operationMetrics),
+	}
+}
+
+func (m *apiMetrics) operation(name string) *operationMetrics {
+	om, ok := m.operations[name]
+	if !ok {
+		om = &operationMetrics{}
+		m.operations[name] = om
+	}
+	return om
+}
+
+func (m *apiMetrics) recordCall(name string, latency time.Duration, failed bool, tries tryMetrics) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	om := m.operation(name)
+	om.Calls++
+	if failed {
+		om.Errors++
+	}
+	om.Retries += max(tries.count-1, 0)
+	om.Throttled += tries.throttled
+	om.TotalSeconds += latency.Seconds()
+	om.MaxSeconds = max(om.MaxSeconds, latency.Seconds())
+}
+
+func (m *apiMetrics) snapshot() map[string]operationMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	operations := make(map[string]operationMetrics, len(m.operations))
+	for name, om := range m.operations {
+		operations[name] = *om
+	}
+	return operations
+}
+
+func (m *apiMetrics) report() error {
+	operations := m.snapshot()
+	if len(operations) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(operations))
+	for name := range operations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		om := operations[name]
+		log.Printf("[INFO] API call summary of %s: calls=%d errors=%d retries=%d throttled=%d avg=%.3fs max=%.3fs",
+			name, om.Calls, om.Errors, om.Retries, om.Throttled, om.TotalSeconds/float64(max(om.Calls, 1)), om.MaxSeconds)
+	}
+
+	if m.file == "" {
+		return nil
+	}
+	b, err := json.Marshal(metricsReport{
+		Time:       time.Now().UTC(),
+		PID:        os.Getpid(),
+		Instance:   m.instance,
+		Operations: operations,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The line is written at once with O_APPEND, so that the lines of concurrent provider processes are not interleaved
+	f, err := os.OpenFile(m.file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// metricsCallPolicy records each call including its retries, so it must be a per-call policy.
+type metricsCallPolicy struct {
+	metrics *apiMetrics
+}
+
+var _ policy.Policy = (*metricsCallPolicy)(nil)
+
+func (p *metricsCallPolicy) Do(req *policy.Request) (*http.Response, error) {
+	tries := &tryMetrics{}
+	req.SetOperationValue(tries)
+
+	start := time.Now()
+	resp, err := req.Next()
+	p.metrics.recordCall(operationName(req.Raw()), time.Since(start), err != nil || resp.StatusCode >= 400, *tries)
+	return resp, err
+}
+
+// tryMetrics is shared by metricsCallPolicy and metricsTryPolicy through the operation value of the request.
+type tryMetrics struct {
+	count     int
+	throttled int
+}
+
+// metricsTryPolicy counts the tries of a call, so it must be a per-retry policy.
+type metricsTryPolicy struct{}
+
+var _ policy.Policy = (*metricsTryPolicy)(nil)
+
+func (p *metricsTryPolicy) Do(req *policy.Request) (*http.Response, error) {
+	var tries *tryMetrics
+	if !req.OperationValue(&tries) {
+		return req.Next()
+	}
+
+	resp, err := req.Next()
+	tries.count++
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		tries.throttled++
+	}
+	return resp, err
+}
+
+// operationName returns the name of the operation of the request, such as "GET /secrets/{name}/versions".
+// Names of secrets and versions are replaced with placeholders so that the calls are aggregated per operation.
+func operationName(req *http.Request) string {
+	vault, ok := vaultRequestOf(req)
+	if !ok {
+		return fmt.Sprintf("%s %s", req.Method, req.URL.Hostname())
+	}
+
+	// The path is /{collection}, /{collection}/{name}, /{collection}/{name}/{version}, or /{collection}/{name}/{action}
+	segments := vault.pathSegments(req.URL)
+	if len(segments) >= 2 {
+		segments[1] = "{name}"
+	}
+	if len(segments) >= 3 && !slices.Contains([]string{"backup", "recover", "versions"}, segments[2]) {
+		segments[2] = "{version}"
+	}
+	return fmt.Sprintf("%s /%s", req.Method, strings.Join(segments, "/"))
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestOperationName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method string
+		url    string
+		vault  *vaultRequest
+		want   string
+	}{
+		{
+			method: http.MethodGet,
+			url:    "https://vault-name.vault.azure.net/secrets/secret-name/0123456789abcdef?api-version=7.6",
+			vault:  testVaultRequest,
+			want:   "GET /secrets/{name}/{version}",
+		},
+		{
+			method: http.MethodGet,
+			url:    "https://vault-name.vault.azure.net/secrets/secret-name/versions",
+			vault:  testVaultRequest,
+			want:   "GET /secrets/{name}/versions",
+		},
+		{
+			method: http.MethodPut,
+			url:    "https://vault-name.vault.azure.net/secrets/secret-name",
+			vault:  testVaultRequest,
+			want:   "PUT /secrets/{name}",
+		},
+		{
+			method: http.MethodDelete,
+			url:    "https://vault-name.vault.azure.net/deletedsecrets/secret-name",
+			vault:  testVaultRequest,
+			want:   "DELETE /deletedsecrets/{name}",
+		},
+		{
+			method: http.MethodGet,
+			url:    "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resources",
+			want:   "GET management.azure.com",
+		},
+		{
+			method: http.MethodGet,
+			url:    "https://localhost:8443/vault-name/secrets/secret-name/versions",
+			vault:  &vaultRequest{vaultName: vaultName, basePath: "/vault-name"},
+			want:   "GET /secrets/{name}/versions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := (&http.Request{Method: tt.method, URL: u}).WithContext(context.Background())
+			if tt.vault != nil {
+				req = req.WithContext(context.WithValue(req.Context(), vaultRequestKey{}, tt.vault))
+			}
+			if got := operationName(req); got != tt.want {
+				t.Errorf("operationName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsPolicies(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("")),
+				Request:    req,
+			}, nil
+		}
+		if strings.Contains(req.URL.Path, "missing") {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"SecretNotFound","message":"not found"}}`)),
+				Request:    req,
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"` + testVaultURL + `/secrets/secret-name/version","value":"secret-value"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	metrics := &apiMetrics{operations: make(map[string]*operationMetrics)}
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport:        transport,
+				Retry:            policy.RetryOptions{RetryDelay: time.Millisecond},
+				PerCallPolicies:  []policy.Policy{&requestLogPolicy{vault: testVaultRequest}, &metricsCallPolicy{metrics: metrics}},
+				PerRetryPolicies: []policy.Policy{&metricsTryPolicy{}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := secretClient.GetSecret(context.Background(), "secret-name", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := secretClient.GetSecret(context.Background(), "missing", "", nil); err == nil {
+		t.Fatal("GetSecret() succeeded unexpectedly")
+	}
+
+	got := metrics.snapshot()
+	om, ok := got["GET /secrets/{name}"]
+	if !ok {
+		t.Fatalf("no metrics of GET /secrets/{name} in %v", got)
+	}
+	if om.Calls != 2 || om.Errors != 1 || om.Retries != 1 || om.Throttled != 1 {
+		t.Errorf("got %+v, want 2 calls, 1 error, 1 retry, and 1 throttled request", om)
+	}
+	if om.MaxSeconds <= 0 || om.TotalSeconds < om.MaxSeconds {
+		t.Errorf("got invalid latencies %+v", om)
+	}
+}
+
+func TestAPIMetricsReport(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "metrics.json")
+	// A line of the previous run is kept
+	if err := os.WriteFile(file, []byte(`{"operations":{}}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := newAPIMetrics(file)
+	metrics.recordCall("PUT /secrets/{name}", 2*time.Second, false, tryMetrics{count: 3, throttled: 2})
+	metrics.recordCall("PUT /secrets/{name}", time.Second, true, tryMetrics{count: 1})
+	otherMetrics := newAPIMetrics(file)
+	otherMetrics.recordCall("GET /secrets/{name}", time.Second, false, tryMetrics{count: 1})
+
+	if err := metrics.report(); err != nil {
+		t.Fatal(err)
+	}
+	if err := otherMetrics.report(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), b)
+	}
+
+	var got, gotOther metricsReport
+	if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &gotOther); err != nil {
+		t.Fatal(err)
+	}
+	if got.PID != os.Getpid() || gotOther.PID != os.Getpid() {
+		t.Errorf("got the pids %d and %d, want %d", got.PID, gotOther.PID, os.Getpid())
+	}
+	if got.Instance == gotOther.Instance {
+		t.Errorf("got the same instance %d for the different metrics", got.Instance)
+	}
+	if got.Time.IsZero() {
+		t.Error("got no time")
+	}
+	want := operationMetrics{Calls: 2, Errors: 1, Retries: 2, Throttled: 2, TotalSeconds: 3, MaxSeconds: 2}
+	if got.Operations["PUT /secrets/{name}"] != want {
+		t.Errorf("got %+v, want %+v", got.Operations["PUT /secrets/{name}"], want)
+	}
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dnsRetryPolicy retries requests while the host can't be resolved or connected until the timeout elapses.
+// The DNS records of a newly created vault, especially with a private endpoint, take time to propagate.
+type dnsRetryPolicy struct {
+	timeout  time.Duration
+	interval time.Duration
+}
+
+var _ policy.Policy = (*dnsRetryPolicy)(nil)
+
+func (p *dnsRetryPolicy) Do(req *policy.Request) (*http.Response, error) {
+	ctx := req.Raw().Context()
+	deadline := time.Now().Add(p.timeout)
+	for {
+		if err := req.RewindBody(); err != nil {
+			return nil, err
+		}
+
+		resp, err := req.Clone(ctx).Next()
+		if err == nil || !isDNSOrConnectionError(err) || time.Now().Add(p.interval).After(deadline) {
+			return resp, err
+		}
+
+		tflog.Debug(ctx, "Retrying because the DNS records of the vault seem not to be propagated yet", map[string]any{"error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// requestTimeoutPolicy sets a deadline on each call including its retries,
+// so that hung connections, e.g. to an unreachable private endpoint, fail fast instead of stalling the run.
+type requestTimeoutPolicy struct {
+	timeout time.Duration
+}
+
+var _ policy.Policy = (*requestTimeoutPolicy)(nil)
+
+func (p *requestTimeoutPolicy) Do(req *policy.Request) (*http.Response, error) {
+	parent := req.Raw().Context()
+	ctx, cancel := context.WithTimeout(parent, p.timeout)
+	defer cancel()
+
+	// The response body has already been read by the pipeline, so the context can be canceled on return
+	resp, err := req.Clone(ctx).Next()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return resp, fmt.Errorf("%s %s didn't complete within request_timeout (%s); make sure that the host is reachable from the network running Terraform: %w",
+			req.Raw().Method, req.Raw().URL.Host, p.timeout, err)
+	}
+	return resp, err
+}
+
+type listPageSizeKey struct{}
+
+// withListPageSize returns the context to request the number of items in a page of a list API.
+func withListPageSize(ctx context.Context, pageSize int32) context.Context {
+	return context.WithValue(ctx, listPageSizeKey{}, pageSize)
+}
+
+// listPageSizePolicy sets the maxresults query parameter to the page size in the context.
+// azsecrets has no option for maxresults, and Key Vault returns 25 items in a page by default.
+type listPageSizePolicy struct{}
+
+var _ policy.Policy = (*listPageSizePolicy)(nil)
+
+func (p *listPageSizePolicy) Do(req *policy.Request) (*http.Response, error) {
+	if pageSize, ok := req.Raw().Context().Value(listPageSizeKey{}).(int32); ok {
+		// The next links also have maxresults, which is replaced with the same value
+		query := req.Raw().URL.Query()
+		query.Set("maxresults", strconv.Itoa(int(pageSize)))
+		req.Raw().URL.RawQuery = query.Encode()
+	}
+	return req.Next()
+}
+
+// isDNSOrConnectionError reports whether the error is caused by a DNS resolution failure or a refused connection.
+func isDNSOrConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// rateLimitPolicy spaces requests so that at most the given number of requests are sent per second,
+// and holds all the requests for the duration of Retry-After once a request is throttled.
+// Without this, hundreds of resources refreshed in parallel keep hitting the throttling limits of Key Vault.
+type rateLimitPolicy struct {
+	interval time.Duration
+	mutex    sync.Mutex
+	next     time.Time
+}
+
+var _ policy.Policy = (*rateLimitPolicy)(nil)
+
+func newRateLimitPolicy(requestsPerSecond int32) *rateLimitPolicy {
+	p := &rateLimitPolicy{}
+	if requestsPerSecond > 0 {
+		p.interval = time.Second / time.Duration(requestsPerSecond)
+	}
+	return p
+}
+
+func (p *rateLimitPolicy) Do(req *policy.Request) (*http.Response, error) {
+	if err := p.wait(req.Raw().Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Next()
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		if delay := retryAfter(resp); delay > 0 {
+			tflog.Debug(req.Raw().Context(), "Holding requests because Key Vault throttles them", map[string]any{"retry_after": delay.String()})
+			p.holdUntil(time.Now().Add(delay))
+		}
+	}
+	return resp, err
+}
+
+// wait blocks until the next request is allowed to be sent.
+func (p *rateLimitPolicy) wait(ctx context.Context) error {
+	p.mutex.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mutex.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+func (p *rateLimitPolicy) holdUntil(t time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.next.Before(t) {
+		p.next = t
+	}
+}
+
+// concurrencyLimitPolicy limits the number of in-flight requests regardless of the parallelism of Terraform,
+// so that the per-vault service limits are respected even when the rest of the plan runs wide.
+// It is a per-retry policy so that requests waiting for retries don't occupy the slots.
+type concurrencyLimitPolicy struct {
+	slots chan struct{}
+}
+
+var _ policy.Policy = (*concurrencyLimitPolicy)(nil)
+
+// newConcurrencyLimitPolicy returns nil if maxConcurrentRequests is not positive, which means no limit.
+func newConcurrencyLimitPolicy(maxConcurrentRequests int32) *concurrencyLimitPolicy {
+	if maxConcurrentRequests <= 0 {
+		return nil
+	}
+	return &concurrencyLimitPolicy{slots: make(chan struct{}, maxConcurrentRequests)}
+}
+
+func (p *concurrencyLimitPolicy) Do(req *policy.Request) (*http.Response, error) {
+	select {
+	case <-req.Raw().Context().Done():
+		return nil, req.Raw().Context().Err()
+	case p.slots <- struct{}{}:
+	}
+	defer func() { <-p.slots }()
+
+	return req.Next()
+}
+
+// retryAfter returns the delay specified by the Retry-After header in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// userAgentPolicy appends the suffix to the User-Agent header set by the telemetry policy of azcore,
+// so that the requests can be identified by a partner ID or a pipeline in the diagnostics logs.
+type userAgentPolicy struct {
+	suffix string
+}
+
+var _ policy.Policy = (*userAgentPolicy)(nil)
+
+func (p *userAgentPolicy) Do(req *policy.Request) (*http.Response, error) {
+	header := req.Raw().Header
+	if userAgent := header.Get("User-Agent"); userAgent != "" {
+		header.Set("User-Agent", userAgent+" "+p.suffix)
+	} else {
+		header.Set("User-Agent", p.suffix)
+	}
+	return req.Next()
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+type transportFunc func(req *http.Request) (*http.Response, error)
+
+func (f transportFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDNSRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		failedCount int
+		err         error
+		timeout     time.Duration
+		wantCalls   int
+		wantErr     bool
+	}{
+		{
+			name:        "DNS records propagated",
+			failedCount: 2,
+			err:         &net.DNSError{Err: "no such host", Name: testVaultURL, IsNotFound: true},
+			timeout:     time.Minute,
+			wantCalls:   3,
+		},
+		{
+			name:        "timed out",
+			failedCount: 2,
+			err:         &net.DNSError{Err: "no such host", Name: testVaultURL, IsNotFound: true},
+			timeout:     time.Nanosecond,
+			wantCalls:   1,
+			wantErr:     true,
+		},
+		{
+			name:        "other error",
+			failedCount: 2,
+			err:         io.ErrUnexpectedEOF,
+			timeout:     time.Minute,
+			wantCalls:   1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if calls <= tt.failedCount {
+					return nil, tt.err
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"id":"` + testVaultURL + `/secrets/secret-name/version","value":"secret-value"}`)),
+					Request:    req,
+				}, nil
+			})
+
+			secretClient, err := azsecrets.NewClient(
+				testVaultURL,
+				&azfake.TokenCredential{},
+				&azsecrets.ClientOptions{
+					ClientOptions: azcore.ClientOptions{
+						PerCallPolicies: []policy.Policy{&dnsRetryPolicy{timeout: tt.timeout, interval: time.Millisecond}},
+						Retry:           policy.RetryOptions{MaxRetries: -1},
+						Transport:       transport,
+					},
+					DisableChallengeResourceVerification: true,
+				},
+			)
+			if err != nil {
+				t.Fatalf("azsecrets.NewClient() error = %v", err)
+			}
+
+			_, err = secretClient.GetSecret(context.Background(), "secret-name", "", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("the request was sent %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutPolicy(t *testing.T) {
+	t.Parallel()
+
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: azcore.ClientOptions{
+				PerCallPolicies: []policy.Policy{&requestTimeoutPolicy{timeout: 10 * time.Millisecond}},
+				Transport:       transport,
+			},
+			DisableChallengeResourceVerification: true,
+		},
+	)
+	if err != nil {
+		t.Fatalf("azsecrets.NewClient() error = %v", err)
+	}
+
+	_, err = secretClient.GetSecret(context.Background(), "secret-name", "", nil)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "request_timeout") {
+		t.Errorf("GetSecret() error = %v, want a deadline exceeded error mentioning request_timeout", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = secretClient.GetSecret(ctx, "secret-name", "", nil)
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "request_timeout") {
+		t.Errorf("GetSecret() error = %v, want the cancellation of the caller", err)
+	}
+}
+
+func TestRateLimitPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		requestsPerSecond int32
+		retryAfter        string
+		requests          int
+		wantMinElapsed    time.Duration
+	}{
+		{
+			name:              "limited",
+			requestsPerSecond: 20,
+			requests:          5,
+			wantMinElapsed:    200 * time.Millisecond,
+		},
+		{
+			name:           "throttled",
+			retryAfter:     "1",
+			requests:       2,
+			wantMinElapsed: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				resp := &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"id":"` + testVaultURL + `/secrets/secret-name/version","value":"secret-value"}`)),
+					Request:    req,
+				}
+				if calls == 1 && tt.retryAfter != "" {
+					resp.StatusCode = http.StatusTooManyRequests
+					resp.Header.Set("Retry-After", tt.retryAfter)
+					resp.Body = io.NopCloser(strings.NewReader(`{"error":{"code":"Throttled"}}`))
+				}
+				return resp, nil
+			})
+
+			secretClient, err := azsecrets.NewClient(
+				testVaultURL,
+				&azfake.TokenCredential{},
+				&azsecrets.ClientOptions{
+					ClientOptions: azcore.ClientOptions{
+						PerRetryPolicies: []policy.Policy{newRateLimitPolicy(tt.requestsPerSecond)},
+						Retry:            policy.RetryOptions{MaxRetries: -1},
+						Transport:        transport,
+					},
+					DisableChallengeResourceVerification: true,
+				},
+			)
+			if err != nil {
+				t.Fatalf("azsecrets.NewClient() error = %v", err)
+			}
+
+			start := time.Now()
+			for range tt.requests {
+				_, _ = secretClient.GetSecret(context.Background(), "secret-name", "", nil)
+			}
+			if elapsed := time.Since(start); elapsed < tt.wantMinElapsed {
+				t.Errorf("%d requests took %s, want at least %s", tt.requests, elapsed, tt.wantMinElapsed)
+			}
+		})
+	}
+}
+
+func TestConcurrencyLimitPolicy(t *testing.T) {
+	t.Parallel()
+
+	const maxConcurrentRequests = 2
+
+	var mutex sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		mutex.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"` + testVaultURL + `/secrets/secret-name/version","value":"secret-value"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: azcore.ClientOptions{
+				PerRetryPolicies: []policy.Policy{newConcurrencyLimitPolicy(maxConcurrentRequests)},
+				Transport:        transport,
+			},
+			DisableChallengeResourceVerification: true,
+		},
+	)
+	if err != nil {
+		t.Fatalf("azsecrets.NewClient() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := secretClient.GetSecret(context.Background(), "secret-name", "", nil); err != nil {
+				t.Errorf("GetSecret() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if maxInFlight != maxConcurrentRequests {
+		t.Errorf("got %d concurrent requests at most, want %d", maxInFlight, maxConcurrentRequests)
+	}
+
+	if p := newConcurrencyLimitPolicy(0); p != nil {
+		t.Errorf("newConcurrencyLimitPolicy(0) = %v, want nil", p)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		wantMin    time.Duration
+		wantMax    time.Duration
+	}{
+		{
+			name: "no header",
+		},
+		{
+			name:       "seconds",
+			retryAfter: "3",
+			wantMin:    3 * time.Second,
+			wantMax:    3 * time.Second,
+		},
+		{
+			name:       "HTTP date",
+			retryAfter: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			wantMin:    58 * time.Second,
+			wantMax:    time.Minute,
+		},
+		{
+			name:       "invalid",
+			retryAfter: "soon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			if got := retryAfter(resp); got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("retryAfter() = %s, want between %s and %s", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestUserAgentPolicy(t *testing.T) {
+	t.Parallel()
+
+	var userAgent string
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"` + testVaultURL + `/secrets/secret-name/version","value":"secret-value"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: azcore.ClientOptions{
+				PerCallPolicies: []policy.Policy{&userAgentPolicy{suffix: "pid-00000000-0000-0000-0000-000000000000 my-pipeline"}},
+				Transport:       transport,
+			},
+			DisableChallengeResourceVerification: true,
+		},
+	)
+	if err != nil {
+		t.Fatalf("azsecrets.NewClient() error = %v", err)
+	}
+
+	if _, err := secretClient.GetSecret(context.Background(), "secret-name", "", nil); err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if !strings.HasPrefix(userAgent, "azsdk-go-azsecrets/") || !strings.HasSuffix(userAgent, " pid-00000000-0000-0000-0000-000000000000 my-pipeline") {
+		t.Errorf("User-Agent = %q, want the SDK user agent followed by the suffix", userAgent)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const roleAssignmentsAPIVersion = "2022-04-01"
+
+// dataPlaneRoleNames maps the IDs of the built-in role definitions that grant Key Vault data actions to their names.
+var dataPlaneRoleNames = map[string]string{
+	"00482a5a-887f-4fb3-b663-3cc16bd7ad8e": "Key Vault Administrator",
+	"a4417e6f-fecd-4de8-b567-7b0420556985": "Key Vault Certificates Officer",
+	"db79e9a7-68ee-4b58-9aeb-b90e7c24fcba": "Key Vault Certificate User",
+	"14b46e9e-c2b7-41b4-b07b-48a6ebf60603": "Key Vault Crypto Officer",
+	"e147488a-f6f5-4113-8e2d-b22465e65bf6": "Key Vault Crypto Service Encryption User",
+	"08bbd89e-9f13-488c-ac41-acfcb10c90ab": "Key Vault Crypto Service Release User",
+	"12338af0-0e69-4776-bea7-57ae8d297424": "Key Vault Crypto User",
+	"21090545-7ca7-4776-b22c-e363652d74d2": "Key Vault Reader",
+	"b86a8fe4-44ce-4948-aee5-eccb2c155cd7": "Key Vault Secrets Officer",
+	"4633458b-17de-408a-b874-0445c86b69e6": "Key Vault Secrets User",
+}
+
+// DataPlaneRoleAssignments is the result of ListDataPlaneRoleAssignments.
+type DataPlaneRoleAssignments struct {
+	// PrincipalID is the object ID of the principal of the credential.
+	PrincipalID string
+	Assignments []DataPlaneRoleAssignment
+}
+
+// DataPlaneRoleAssignment is an assignment of a built-in Key Vault data-plane role.
+type DataPlaneRoleAssignment struct {
+	ID               string
+	RoleName         string
+	RoleDefinitionID string
+	Scope            string
+}
+
+// ListDataPlaneRoleAssignments returns the assignments of the built-in Key Vault data-plane roles to the principal of the credential,
+// including those to the groups of the principal, at, above, or below the scope of the key vault.
+func (c *client) ListDataPlaneRoleAssignments(ctx context.Context, keyVaultID string) (*DataPlaneRoleAssignments, error) {
+	cred, err := c.credential()
+	if err != nil {
+		return nil, err
+	}
+	// The token is cached by the credential, so this usually doesn't call Microsoft Entra ID
+	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{"https://vault.azure.net/.default"}})
+	if err != nil {
+		return nil, err
+	}
+	caller := callerFromToken(token.Token)
+	if caller == nil || caller.ObjectID == "" {
+		return nil, errors.New("the object ID of the principal can't be extracted from the access token")
+	}
+
+	assignments, err := listDataPlaneRoleAssignments(ctx, cred, &c.resourceClientOptions, keyVaultID, caller.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &DataPlaneRoleAssignments{PrincipalID: caller.ObjectID, Assignments: assignments}, nil
+}
+
+// listDataPlaneRoleAssignments lists the role assignments to the principal for the scope and filters out the roles other than
+// the built-in Key Vault data-plane roles, which doesn't require the permission to read role definitions.
+// The API is called directly since the SDK of the authorization API is not worth another dependency.
+func listDataPlaneRoleAssignments(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions, scope, principalID string) ([]DataPlaneRoleAssignment, error) {
+	armClient, err := arm.NewClient("azurekv", "v0.0.0", cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("api-version", roleAssignmentsAPIVersion)
+	query.Set("$filter", "assignedTo('"+principalID+"')")
+
+	var assignments []DataPlaneRoleAssignment
+	nextLink := runtime.JoinPaths(armClient.Endpoint(), scope, "/providers/Microsoft.Authorization/roleAssignments") + "?" + query.Encode()
+	for nextLink != "" {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := armClient.Pipeline().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, runtime.NewResponseError(resp)
+		}
+
+		var page struct {
+			Value []struct {
+				ID         string `json:"id"`
+				Properties struct {
+					RoleDefinitionID string `json:"roleDefinitionId"`
+					Scope            string `json:"scope"`
+				} `json:"properties"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		for _, a := range page.Value {
+			roleName, ok := dataPlaneRoleNames[strings.ToLower(path.Base(a.Properties.RoleDefinitionID))]
+			if !ok {
+				continue
+			}
+			assignments = append(assignments, DataPlaneRoleAssignment{
+				ID:               a.ID,
+				RoleName:         roleName,
+				RoleDefinitionID: a.Properties.RoleDefinitionID,
+				Scope:            a.Properties.Scope,
+			})
+		}
+		nextLink = page.NextLink
+	}
+
+	return assignments, nil
+}
+
+// coversKeyVault returns whether the role assignment applies to the whole key vault, i.e. is not scoped to an object in it.
+func (a DataPlaneRoleAssignment) coversKeyVault(keyVaultID string) bool {
+	// Resource IDs are case-insensitive
+	return !strings.HasPrefix(strings.ToLower(a.Scope), strings.ToLower(keyVaultID)+"/")
+}
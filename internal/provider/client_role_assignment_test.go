@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClientListDataPlaneRoleAssignments(t *testing.T) {
+	t.Parallel()
+
+	const principalID = "11111111-1111-1111-1111-111111111111"
+	secretsUserID := "/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/4633458b-17de-408a-b874-0445c86b69e6"
+	secretsOfficerID := "/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/B86A8FE4-44CE-4948-AEE5-ECCB2C155CD7"
+	contributorID := "/subscriptions/sub/providers/Microsoft.Authorization/roleDefinitions/b24988ac-6180-42a0-ab88-20f7382dd24c"
+	pages := map[string]string{
+		"": `{"value":[` +
+			`{"id":"/subscriptions/sub/providers/Microsoft.Authorization/roleAssignments/a1","properties":{"roleDefinitionId":"` + secretsUserID + `","scope":"/subscriptions/sub"}},` +
+			`{"id":"/subscriptions/sub/providers/Microsoft.Authorization/roleAssignments/a2","properties":{"roleDefinitionId":"` + contributorID + `","scope":"/subscriptions/sub"}}` +
+			`],"nextLink":"https://management.azure.com` + testKeyVaultID + `/providers/Microsoft.Authorization/roleAssignments?page=2"}`,
+		"2": `{"value":[` +
+			`{"id":"` + testKeyVaultID + `/secrets/secret-name/providers/Microsoft.Authorization/roleAssignments/a3","properties":{"roleDefinitionId":"` + secretsOfficerID + `","scope":"` + testKeyVaultID + `/secrets/secret-name"}}` +
+			`]}`,
+	}
+
+	c, err := NewClient("sub", &ClientOptions{
+		Credential: &staticTokenCredential{token: testJWT(`{"oid":"` + principalID + `"}`)},
+		Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+			if want := testKeyVaultID + "/providers/Microsoft.Authorization/roleAssignments"; req.URL.Path != want {
+				t.Errorf("got a request to %s, want %s", req.URL.Path, want)
+			}
+			page := req.URL.Query().Get("page")
+			if page == "" {
+				if got, want := req.URL.Query().Get("$filter"), "assignedTo('"+principalID+"')"; got != want {
+					t.Errorf("got $filter %q, want %q", got, want)
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(pages[page])),
+				Request:    req,
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	got, err := c.ListDataPlaneRoleAssignments(t.Context(), testKeyVaultID)
+	if err != nil {
+		t.Fatalf("ListDataPlaneRoleAssignments() error = %v", err)
+	}
+	want := &DataPlaneRoleAssignments{
+		PrincipalID: principalID,
+		Assignments: []DataPlaneRoleAssignment{
+			{
+				ID:               "/subscriptions/sub/providers/Microsoft.Authorization/roleAssignments/a1",
+				RoleName:         "Key Vault Secrets User",
+				RoleDefinitionID: secretsUserID,
+				Scope:            "/subscriptions/sub",
+			},
+			{
+				ID:               testKeyVaultID + "/secrets/secret-name/providers/Microsoft.Authorization/roleAssignments/a3",
+				RoleName:         "Key Vault Secrets Officer",
+				RoleDefinitionID: secretsOfficerID,
+				Scope:            testKeyVaultID + "/secrets/secret-name",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListDataPlaneRoleAssignments() = %+v, want %+v", got, want)
+	}
+}
+
+func TestClientListDataPlaneRoleAssignmentsWithoutObjectID(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient("sub", &ClientOptions{
+		Credential: &staticTokenCredential{token: "token"},
+		Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("got an unexpected request to %s", req.URL)
+			return nil, io.EOF
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := c.ListDataPlaneRoleAssignments(t.Context(), testKeyVaultID); err == nil {
+		t.Error("ListDataPlaneRoleAssignments() with an opaque token succeeded unexpectedly")
+	}
+}
+
+func TestDataPlaneRoleAssignmentCoversKeyVault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "/", want: true},
+		{scope: "/providers/Microsoft.Management/managementGroups/group", want: true},
+		{scope: "/subscriptions/sub", want: true},
+		{scope: testKeyVaultID, want: true},
+		{scope: strings.ToUpper(testKeyVaultID), want: true},
+		{scope: testKeyVaultID + "/secrets/secret-name", want: false},
+		{scope: strings.ToUpper(testKeyVaultID) + "/secrets/secret-name", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			t.Parallel()
+
+			a := DataPlaneRoleAssignment{Scope: tt.scope}
+			if got := a.coversKeyVault(testKeyVaultID); got != tt.want {
+				t.Errorf("coversKeyVault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const subscriptionsAPIVersion = "2022-12-01"
+
+// discoverSubscriptionID returns the default subscription of the Azure CLI if any,
+// or the only enabled subscription accessible with the credential.
+func discoverSubscriptionID(ctx context.Context, credential func() (azcore.TokenCredential, error), options *arm.ClientOptions) (string, error) {
+	subscriptionID, err := azureCLIDefaultSubscriptionID()
+	if err != nil {
+		return "", err
+	}
+	if subscriptionID != "" {
+		return subscriptionID, nil
+	}
+
+	cred, err := credential()
+	if err != nil {
+		return "", err
+	}
+	subscriptionIDs, err := listSubscriptionIDs(ctx, cred, options)
+	if err != nil {
+		return "", err
+	}
+	switch len(subscriptionIDs) {
+	case 0:
+		return "", errors.New("no enabled subscriptions are accessible with the credential")
+	case 1:
+		return subscriptionIDs[0], nil
+	default:
+		return "", fmt.Errorf("multiple subscriptions are accessible with the credential, so specify one of them with subscription_id: %s", strings.Join(subscriptionIDs, ", "))
+	}
+}
+
+// azureCLIDefaultSubscriptionID returns the default subscription in the profile of the Azure CLI,
+// or an empty string if the Azure CLI is not logged in.
+func azureCLIDefaultSubscriptionID() (string, error) {
+	configDir := os.Getenv("AZURE_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", nil
+		}
+		configDir = filepath.Join(home, ".azure")
+	}
+
+	b, err := os.ReadFile(filepath.Join(configDir, "azureProfile.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var profile struct {
+		Subscriptions []struct {
+			ID        string `json:"id"`
+			IsDefault bool   `json:"isDefault"`
+		} `json:"subscriptions"`
+	}
+	// The Azure CLI writes the profile with a byte order mark
+	if err := json.Unmarshal(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf")), &profile); err != nil {
+		return "", fmt.Errorf("failed to parse the profile of the Azure CLI: %w", err)
+	}
+	for _, s := range profile.Subscriptions {
+		if s.IsDefault {
+			return s.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// listSubscriptionIDs returns the IDs of the enabled subscriptions accessible with the credential.
+// The API is called directly since the SDK of the subscriptions API is not worth another dependency.
+func listSubscriptionIDs(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions) ([]string, error) {
+	armClient, err := arm.NewClient("azurekv", "v0.0.0", cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptionIDs []string
+	nextLink := runtime.JoinPaths(armClient.Endpoint(), "/subscriptions") + "?api-version=" + subscriptionsAPIVersion
+	for nextLink != "" {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := armClient.Pipeline().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !runtime.HasStatusCode(resp, http.StatusOK) {
+			return nil, runtime.NewResponseError(resp)
+		}
+
+		var page struct {
+			Value []struct {
+				SubscriptionID string `json:"subscriptionId"`
+				State          string `json:"state"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := runtime.UnmarshalAsJSON(resp, &page); err != nil {
+			return nil, err
+		}
+		for _, s := range page.Value {
+			if s.State == "Enabled" {
+				subscriptionIDs = append(subscriptionIDs, s.SubscriptionID)
+			}
+		}
+		nextLink = page.NextLink
+	}
+
+	return subscriptionIDs, nil
+}
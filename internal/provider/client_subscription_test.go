@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+func TestAzureCLIDefaultSubscriptionID(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZURE_CONFIG_DIR", configDir)
+
+	got, err := azureCLIDefaultSubscriptionID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Errorf("azureCLIDefaultSubscriptionID() = %q, want an empty string without the profile", got)
+	}
+
+	profile := "\xef\xbb\xbf" + `{"subscriptions":[{"id":"00000000-0000-0000-0000-000000000001","isDefault":false},{"id":"00000000-0000-0000-0000-000000000002","isDefault":true}]}`
+	if err := os.WriteFile(filepath.Join(configDir, "azureProfile.json"), []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = azureCLIDefaultSubscriptionID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "00000000-0000-0000-0000-000000000002"; got != want {
+		t.Errorf("azureCLIDefaultSubscriptionID() = %q, want %q", got, want)
+	}
+}
+
+func TestDiscoverSubscriptionID(t *testing.T) {
+	t.Setenv("AZURE_CONFIG_DIR", t.TempDir())
+
+	credential := func() (azcore.TokenCredential, error) {
+		return &staticTokenCredential{token: "token"}, nil
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "one enabled subscription",
+			body: `{"value":[{"subscriptionId":"00000000-0000-0000-0000-000000000001","state":"Enabled"},{"subscriptionId":"00000000-0000-0000-0000-000000000002","state":"Disabled"}]}`,
+			want: "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:    "multiple enabled subscriptions",
+			body:    `{"value":[{"subscriptionId":"00000000-0000-0000-0000-000000000001","state":"Enabled"},{"subscriptionId":"00000000-0000-0000-0000-000000000002","state":"Enabled"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no subscriptions",
+			body:    `{"value":[]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/subscriptions" {
+					t.Errorf("got a request to %s, want /subscriptions", req.URL.Path)
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+					Request:    req,
+				}, nil
+			})
+
+			got, err := discoverSubscriptionID(context.Background(), credential, &arm.ClientOptions{
+				ClientOptions: policy.ClientOptions{Transport: transport},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("discoverSubscriptionID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("discoverSubscriptionID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,14 +1,26 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	armresourcesfake "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources/fake"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 	azsecretsfake "github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets/fake"
 )
@@ -20,6 +32,8 @@ const (
 var (
 	testKeyVaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/" + vaultName
 	testVaultURL   = "https://" + vaultName + ".vault.azure.net"
+	// testVaultRequest is attached to the requests to testVaultURL by requestLogPolicy
+	testVaultRequest = &vaultRequest{vaultName: vaultName}
 )
 
 func newTestClient(t *testing.T, fakeServer *azsecretsfake.Server) *client {
@@ -45,6 +59,139 @@ func newTestClient(t *testing.T, fakeServer *azsecretsfake.Server) *client {
 	}
 }
 
+func TestNewClientWithTransport(t *testing.T) {
+	t.Parallel()
+
+	secretsTransport := azsecretsfake.NewServerTransport(&azsecretsfake.Server{
+		NewListSecretPropertiesVersionsPager: func(
+			_ string,
+			_ *azsecrets.ListSecretPropertiesVersionsOptions,
+		) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+			resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+				SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{
+					Value: []*azsecrets.SecretProperties{secretProperties("version-1", 100)},
+				},
+			}, nil)
+			return
+		},
+	})
+	resourcesTransport := armresourcesfake.NewServerTransport(&armresourcesfake.Server{
+		NewListPager: func(_ *armresources.ClientListOptions) (resp azfake.PagerResponder[armresources.ClientListResponse]) {
+			resp.AddPage(http.StatusOK, armresources.ClientListResponse{
+				ResourceListResult: armresources.ResourceListResult{
+					Value: []*armresources.GenericResourceExpanded{{ID: to.Ptr(testKeyVaultID)}},
+				},
+			}, nil)
+			return
+		},
+	})
+
+	c, err := NewClient("sub", &ClientOptions{
+		Credential: &azfake.TokenCredential{},
+		Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Hostname() == vaultName+".vault.azure.net" {
+				return secretsTransport.Do(req)
+			}
+			return resourcesTransport.Do(req)
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	id, err := c.GetKeyVaultID(t.Context(), vaultName, nil)
+	if err != nil {
+		t.Fatalf("GetKeyVaultID() error = %v", err)
+	}
+	if id != testKeyVaultID {
+		t.Errorf("GetKeyVaultID() = %q, want %q", id, testKeyVaultID)
+	}
+
+	props, err := c.GetSecretProperties(t.Context(), testKeyVaultID, "secret-name", "", nil)
+	if err != nil {
+		t.Fatalf("GetSecretProperties() error = %v", err)
+	}
+	if got := props.ID.Version(); got != "version-1" {
+		t.Errorf("GetSecretProperties() returned version %q, want %q", got, "version-1")
+	}
+}
+
+func TestClientGetSecretClientIgnoresCase(t *testing.T) {
+	t.Parallel()
+
+	newClient, err := NewClient("sub", &ClientOptions{Credential: &azfake.TokenCredential{}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	c := newClient.(*client)
+
+	// Key vault names are case-insensitive, so the IDs differing only in case refer to the same key vault
+	want, err := c.getSecretClient(strings.Replace(testKeyVaultID, vaultName, "Vault-Name", 1))
+	if err != nil {
+		t.Fatalf("getSecretClient() error = %v", err)
+	}
+	got, err := c.getSecretClient(testKeyVaultID)
+	if err != nil {
+		t.Fatalf("getSecretClient() error = %v", err)
+	}
+	if got != want {
+		t.Error("getSecretClient() returned different clients for the IDs differing only in case")
+	}
+}
+
+func TestNewClientWithVaultEndpoint(t *testing.T) {
+	t.Parallel()
+
+	emulator := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Key Vault requires the challenge before accepting tokens
+		if r.Header.Get("Authorization") == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer authorization="https://login.microsoftonline.com/tenant", resource="https://localhost"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer static-token" {
+			t.Errorf("got Authorization %q, want %q", got, "Bearer static-token")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/secrets/missing-secret/") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"error":{"code":"SecretNotFound","message":"not found"}}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"value":[{"id":"https://%s/secrets/secret-name/version-1","attributes":{"created":100}}]}`, r.Host)
+	}))
+	defer emulator.Close()
+
+	c, err := NewClient("", &ClientOptions{
+		Credential:         &staticTokenCredential{token: "static-token"},
+		VaultEndpoint:      emulator.URL,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	props, err := c.GetSecretProperties(t.Context(), testKeyVaultID, "secret-name", "", nil)
+	if err != nil {
+		t.Fatalf("GetSecretProperties() error = %v", err)
+	}
+	if got := props.ID.Version(); got != "version-1" {
+		t.Errorf("GetSecretProperties() returned version %q, want %q", got, "version-1")
+	}
+
+	// The vault name is not derived from the host of the emulator
+	_, err = c.GetSecretProperties(t.Context(), testKeyVaultID, "missing-secret", "", nil)
+	if !isNotFoundError(err) {
+		t.Fatalf("GetSecretProperties() error = %v, want not found", err)
+	}
+	details := azureRequestDetails(err)
+	for _, want := range []string{"Vault name: " + vaultName, "Operation: GET /secrets/{name}/versions"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("azureRequestDetails() = %q, want it to contain %q", details, want)
+		}
+	}
+}
+
 func secretProperties(version string, created int64) *azsecrets.SecretProperties {
 	createdAt := time.Unix(created, 0)
 
@@ -147,3 +294,977 @@ func TestClientGetSecretProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestClientListSecretProperties(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var maxResults []string
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		maxResults = append(maxResults, req.URL.Query().Get("maxresults"))
+		mu.Unlock()
+
+		body := `{"value":[{"id":"` + testVaultURL + `/secrets/secret-1"},{"id":"` + testVaultURL + `/secrets/secret-2"}],"nextLink":"` + testVaultURL + `/secrets?$skiptoken=token&maxresults=2"}`
+		if req.URL.Query().Get("$skiptoken") != "" {
+			body = `{"value":[{"id":"` + testVaultURL + `/secrets/secret-3"}]}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: azcore.ClientOptions{
+				PerCallPolicies: []policy.Policy{&listPageSizePolicy{}},
+				Transport:       transport,
+			},
+			DisableChallengeResourceVerification: true,
+		},
+	)
+	if err != nil {
+		t.Fatalf("azsecrets.NewClient() error = %v", err)
+	}
+	c := &client{
+		secretClients: map[string]*azsecrets.Client{
+			vaultName: secretClient,
+		},
+	}
+
+	var names []string
+	for secret, err := range c.ListSecretProperties(t.Context(), testKeyVaultID, &ListSecretPropertiesOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("ListSecretProperties() error = %v", err)
+		}
+		names = append(names, secret.ID.Name())
+	}
+	if want := []string{"secret-1", "secret-2", "secret-3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListSecretProperties() yielded %v, want %v", names, want)
+	}
+	if want := []string{"2", "2"}; !reflect.DeepEqual(maxResults, want) {
+		t.Errorf("maxresults = %v, want %v", maxResults, want)
+	}
+
+	// The next page is not requested once the loop breaks
+	maxResults = nil
+	for _, err := range c.ListSecretProperties(t.Context(), testKeyVaultID, nil) {
+		if err != nil {
+			t.Fatalf("ListSecretProperties() error = %v", err)
+		}
+		break
+	}
+	if want := []string{""}; !reflect.DeepEqual(maxResults, want) {
+		t.Errorf("maxresults = %v, want %v", maxResults, want)
+	}
+}
+
+func TestClientSetSecretWaitsForVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		visibleAt   int
+		timeout     time.Duration
+		wantListing int
+	}{
+		{
+			name:        "visible immediately",
+			visibleAt:   1,
+			timeout:     time.Minute,
+			wantListing: 1,
+		},
+		{
+			name:        "visible eventually",
+			visibleAt:   3,
+			timeout:     time.Minute,
+			wantListing: 3,
+		},
+		{
+			name:        "timed out",
+			visibleAt:   10,
+			timeout:     time.Millisecond,
+			wantListing: 1,
+		},
+		{
+			name:        "disabled",
+			visibleAt:   1,
+			wantListing: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			listing := 0
+			fakeServer := azsecretsfake.Server{
+				SetSecret: func(
+					_ context.Context,
+					_ string,
+					_ azsecrets.SetSecretParameters,
+					_ *azsecrets.SetSecretOptions,
+				) (resp azfake.Responder[azsecrets.SetSecretResponse], errResp azfake.ErrorResponder) {
+					resp.SetResponse(http.StatusOK, azsecrets.SetSecretResponse{
+						Secret: azsecrets.Secret{ID: to.Ptr(azsecrets.ID(testVaultURL + "/secrets/secret-name/version-2"))},
+					}, nil)
+					return
+				},
+				NewListSecretPropertiesVersionsPager: func(
+					_ string,
+					_ *azsecrets.ListSecretPropertiesVersionsOptions,
+				) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+					listing++
+					versions := []*azsecrets.SecretProperties{secretProperties("version-1", 100)}
+					if listing >= tt.visibleAt {
+						versions = append(versions, secretProperties("version-2", 200))
+					}
+					resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+						SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{Value: versions},
+					}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+			c.pollInterval = time.Millisecond
+			c.options.VersionPropagationTimeout = tt.timeout
+
+			if _, err := c.SetSecret(t.Context(), testKeyVaultID, "secret-name", azsecrets.SetSecretParameters{Value: to.Ptr("value")}, nil); err != nil {
+				t.Fatalf("SetSecret() error = %v", err)
+			}
+			if listing != tt.wantListing {
+				t.Errorf("listed versions %d times, want %d", listing, tt.wantListing)
+			}
+		})
+	}
+}
+
+func TestClientPurgeDeletedSecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		notFoundCount   int
+		getDeletedError int
+		wantPurged      bool
+		wantErr         bool
+	}{
+		{
+			name:       "already deleted",
+			wantPurged: true,
+		},
+		{
+			name:          "deletion in progress",
+			notFoundCount: 2,
+			wantPurged:    true,
+		},
+		{
+			name:            "forbidden",
+			getDeletedError: http.StatusForbidden,
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getDeletedCount := 0
+			purged := false
+			fakeServer := azsecretsfake.Server{
+				GetDeletedSecret: func(
+					_ context.Context,
+					_ string,
+					_ *azsecrets.GetDeletedSecretOptions,
+				) (resp azfake.Responder[azsecrets.GetDeletedSecretResponse], errResp azfake.ErrorResponder) {
+					getDeletedCount++
+					if tt.getDeletedError != 0 {
+						errResp.SetResponseError(tt.getDeletedError, "Forbidden")
+						return
+					}
+					if getDeletedCount <= tt.notFoundCount {
+						errResp.SetResponseError(http.StatusNotFound, "SecretNotFound")
+						return
+					}
+					resp.SetResponse(http.StatusOK, azsecrets.GetDeletedSecretResponse{}, nil)
+					return
+				},
+				PurgeDeletedSecret: func(
+					_ context.Context,
+					_ string,
+					_ *azsecrets.PurgeDeletedSecretOptions,
+				) (resp azfake.Responder[azsecrets.PurgeDeletedSecretResponse], errResp azfake.ErrorResponder) {
+					purged = true
+					resp.SetResponse(http.StatusNoContent, azsecrets.PurgeDeletedSecretResponse{}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+
+			_, err := c.PurgeDeletedSecret(t.Context(), testKeyVaultID, "secret-name", nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("PurgeDeletedSecret() error = nil, want an error")
+				}
+			} else if err != nil {
+				t.Fatalf("PurgeDeletedSecret() error = %v", err)
+			}
+
+			if purged != tt.wantPurged {
+				t.Errorf("purged = %v, want %v", purged, tt.wantPurged)
+			}
+			if want := tt.notFoundCount + 1; getDeletedCount != want {
+				t.Errorf("GetDeletedSecret() called %d times, want %d", getDeletedCount, want)
+			}
+		})
+	}
+}
+
+func TestSetSecretRecoveringDeleted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		conflictCode  string
+		wantRecovered bool
+		wantErr       bool
+	}{
+		{
+			name:          "deleted but recoverable",
+			conflictCode:  "ObjectIsDeletedButRecoverable",
+			wantRecovered: true,
+		},
+		{
+			// The secret may be being purged, so the error is left to retryWhileBeingDeleted
+			name:         "being deleted",
+			conflictCode: "ObjectIsBeingDeleted",
+			wantErr:      true,
+		},
+		{
+			name:         "other conflict",
+			conflictCode: "Conflict",
+			wantErr:      true,
+		},
+		{
+			name: "no conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			setCount := 0
+			recovered := false
+			fakeServer := azsecretsfake.Server{
+				SetSecret: func(
+					_ context.Context,
+					_ string,
+					_ azsecrets.SetSecretParameters,
+					_ *azsecrets.SetSecretOptions,
+				) (resp azfake.Responder[azsecrets.SetSecretResponse], errResp azfake.ErrorResponder) {
+					setCount++
+					if setCount == 1 && tt.conflictCode != "" {
+						errResp.SetResponseError(http.StatusConflict, tt.conflictCode)
+						return
+					}
+					resp.SetResponse(http.StatusOK, azsecrets.SetSecretResponse{
+						Secret: azsecrets.Secret{ID: to.Ptr(azsecrets.ID(testVaultURL + "/secrets/secret-name/version-2"))},
+					}, nil)
+					return
+				},
+				GetDeletedSecret: func(
+					_ context.Context,
+					_ string,
+					_ *azsecrets.GetDeletedSecretOptions,
+				) (resp azfake.Responder[azsecrets.GetDeletedSecretResponse], errResp azfake.ErrorResponder) {
+					resp.SetResponse(http.StatusOK, azsecrets.GetDeletedSecretResponse{}, nil)
+					return
+				},
+				RecoverDeletedSecret: func(
+					_ context.Context,
+					_ string,
+					_ *azsecrets.RecoverDeletedSecretOptions,
+				) (resp azfake.Responder[azsecrets.RecoverDeletedSecretResponse], errResp azfake.ErrorResponder) {
+					recovered = true
+					resp.SetResponse(http.StatusOK, azsecrets.RecoverDeletedSecretResponse{}, nil)
+					return
+				},
+				NewListSecretPropertiesVersionsPager: func(
+					_ string,
+					_ *azsecrets.ListSecretPropertiesVersionsOptions,
+				) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+					resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+						SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{
+							Value: []*azsecrets.SecretProperties{secretProperties("version-1", 100)},
+						},
+					}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+			c.pollInterval = time.Millisecond
+
+			resp, err := setSecretRecoveringDeleted(t.Context(), c, testKeyVaultID, "secret-name", azsecrets.SetSecretParameters{Value: to.Ptr("value")}, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("setSecretRecoveringDeleted() error = nil, want an error")
+				}
+			} else if err != nil {
+				t.Fatalf("setSecretRecoveringDeleted() error = %v", err)
+			} else if got := resp.ID.Version(); got != "version-2" {
+				t.Errorf("setSecretRecoveringDeleted() returned version %q, want %q", got, "version-2")
+			}
+
+			if recovered != tt.wantRecovered {
+				t.Errorf("recovered = %v, want %v", recovered, tt.wantRecovered)
+			}
+		})
+	}
+}
+
+func TestSetSecretRecoveringDeletedWhilePurging(t *testing.T) {
+	t.Parallel()
+
+	setCount := 0
+	fakeServer := azsecretsfake.Server{
+		SetSecret: func(
+			_ context.Context,
+			_ string,
+			_ azsecrets.SetSecretParameters,
+			_ *azsecrets.SetSecretOptions,
+		) (resp azfake.Responder[azsecrets.SetSecretResponse], errResp azfake.ErrorResponder) {
+			setCount++
+			// The purge completes after a few tries
+			if setCount <= 2 {
+				errResp.SetResponseError(http.StatusConflict, "ObjectIsBeingDeleted")
+				return
+			}
+			resp.SetResponse(http.StatusOK, azsecrets.SetSecretResponse{
+				Secret: azsecrets.Secret{ID: to.Ptr(azsecrets.ID(testVaultURL + "/secrets/secret-name/version-1"))},
+			}, nil)
+			return
+		},
+		RecoverDeletedSecret: func(
+			_ context.Context,
+			_ string,
+			_ *azsecrets.RecoverDeletedSecretOptions,
+		) (resp azfake.Responder[azsecrets.RecoverDeletedSecretResponse], errResp azfake.ErrorResponder) {
+			t.Error("RecoverDeletedSecret() is called for the secret being purged")
+			errResp.SetResponseError(http.StatusNotFound, "SecretNotFound")
+			return
+		},
+	}
+	c := newTestClient(t, &fakeServer)
+
+	// This is how azurekv_secret sets the secret with recover_soft_deleted_secrets and purge_wait_timeout
+	var resp azsecrets.SetSecretResponse
+	err := retryWhileBeingDeleted(t.Context(), time.Minute, time.Millisecond, func() (err error) {
+		resp, err = setSecretRecoveringDeleted(t.Context(), c, testKeyVaultID, "secret-name", azsecrets.SetSecretParameters{Value: to.Ptr("value")}, nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("setSecretRecoveringDeleted() error = %v", err)
+	}
+	if got := resp.ID.Version(); got != "version-1" {
+		t.Errorf("setSecretRecoveringDeleted() returned version %q, want %q", got, "version-1")
+	}
+	if setCount != 3 {
+		t.Errorf("SetSecret() was called %d times, want 3", setCount)
+	}
+}
+
+func TestIsForbiddenByRBACError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "inner error code",
+			err:  newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"Caller is not authorized","innererror":{"code":"ForbiddenByRbac"}}}`),
+			want: true,
+		},
+		{
+			name: "code in the message",
+			err:  newTestResponseError(t, http.StatusForbidden, `{"error":{"code":"Forbidden","message":"The request was not ForbiddenByRbac","innererror":{"code":"ForbiddenByFirewall"}}}`),
+		},
+		{
+			name: "other status",
+			err:  newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","innererror":{"code":"ForbiddenByRbac"}}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isForbiddenByRBACError(tt.err); got != tt.want {
+				t.Errorf("isForbiddenByRBACError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSoftDeletedConflictError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "inner error code",
+			err:  newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","message":"Secret is currently in a deleted but recoverable state","innererror":{"code":"ObjectIsDeletedButRecoverable"}}}`),
+			want: true,
+		},
+		{
+			name: "code in the message",
+			err:  newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","message":"The secret is not ObjectIsDeletedButRecoverable","innererror":{"code":"ObjectIsBeingDeleted"}}}`),
+		},
+		{
+			name: "being deleted",
+			err:  newTestResponseError(t, http.StatusConflict, `{"error":{"code":"Conflict","innererror":{"code":"ObjectIsBeingDeleted"}}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isSoftDeletedConflictError(tt.err); got != tt.want {
+				t.Errorf("isSoftDeletedConflictError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryOnForbiddenByRBAC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		errorCode      string
+		forbiddenCount int
+		timeout        time.Duration
+		wantCalls      int
+		wantErr        bool
+	}{
+		{
+			name:      "no error",
+			timeout:   time.Minute,
+			wantCalls: 1,
+		},
+		{
+			name:           "role assignments propagated",
+			errorCode:      "ForbiddenByRbac",
+			forbiddenCount: 2,
+			timeout:        time.Minute,
+			wantCalls:      3,
+		},
+		{
+			name:           "retries disabled",
+			errorCode:      "ForbiddenByRbac",
+			forbiddenCount: 2,
+			wantCalls:      1,
+			wantErr:        true,
+		},
+		{
+			name:           "other forbidden error",
+			errorCode:      "ForbiddenByPolicy",
+			forbiddenCount: 2,
+			timeout:        time.Minute,
+			wantCalls:      1,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			fakeServer := azsecretsfake.Server{
+				SetSecret: func(
+					_ context.Context,
+					_ string,
+					_ azsecrets.SetSecretParameters,
+					_ *azsecrets.SetSecretOptions,
+				) (resp azfake.Responder[azsecrets.SetSecretResponse], errResp azfake.ErrorResponder) {
+					calls++
+					if calls <= tt.forbiddenCount {
+						errResp.SetResponseError(http.StatusForbidden, tt.errorCode)
+						return
+					}
+					resp.SetResponse(http.StatusOK, azsecrets.SetSecretResponse{}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+
+			err := retryOnForbiddenByRBAC(context.Background(), tt.timeout, time.Millisecond, func() error {
+				_, err := c.SetSecret(context.Background(), testKeyVaultID, "secret-name", azsecrets.SetSecretParameters{}, nil)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryOnForbiddenByRBAC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("SetSecret() was called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryWhileBeingDeleted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		errorCode     string
+		conflictCount int
+		timeout       time.Duration
+		wantCalls     int
+		wantErr       bool
+	}{
+		{
+			name:      "no error",
+			timeout:   time.Minute,
+			wantCalls: 1,
+		},
+		{
+			name:          "deletion completed",
+			errorCode:     "ObjectIsBeingDeleted",
+			conflictCount: 2,
+			timeout:       time.Minute,
+			wantCalls:     3,
+		},
+		{
+			name:          "retries disabled",
+			errorCode:     "ObjectIsBeingDeleted",
+			conflictCount: 2,
+			wantCalls:     1,
+			wantErr:       true,
+		},
+		{
+			name:          "other conflict error",
+			errorCode:     "ObjectIsDeletedButRecoverable",
+			conflictCount: 2,
+			timeout:       time.Minute,
+			wantCalls:     1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			fakeServer := azsecretsfake.Server{
+				SetSecret: func(
+					_ context.Context,
+					_ string,
+					_ azsecrets.SetSecretParameters,
+					_ *azsecrets.SetSecretOptions,
+				) (resp azfake.Responder[azsecrets.SetSecretResponse], errResp azfake.ErrorResponder) {
+					calls++
+					if calls <= tt.conflictCount {
+						errResp.SetResponseError(http.StatusConflict, tt.errorCode)
+						return
+					}
+					resp.SetResponse(http.StatusOK, azsecrets.SetSecretResponse{}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+
+			err := retryWhileBeingDeleted(context.Background(), tt.timeout, time.Millisecond, func() error {
+				_, err := c.SetSecret(context.Background(), testKeyVaultID, "secret-name", azsecrets.SetSecretParameters{}, nil)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryWhileBeingDeleted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("SetSecret() was called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientGetKeyVaultIDConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fakeServer := armresourcesfake.Server{
+		NewListPager: func(_ *armresources.ClientListOptions) (resp azfake.PagerResponder[armresources.ClientListResponse]) {
+			calls.Add(1)
+			<-release
+			resp.AddPage(http.StatusOK, armresources.ClientListResponse{
+				ResourceListResult: armresources.ResourceListResult{
+					Value: []*armresources.GenericResourceExpanded{{ID: to.Ptr(testKeyVaultID)}},
+				},
+			}, nil)
+			return
+		},
+	}
+	resourceClient, err := armresources.NewClient("sub", &azfake.TokenCredential{}, &arm.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Transport: armresourcesfake.NewServerTransport(&fakeServer),
+		},
+	})
+	if err != nil {
+		t.Fatalf("armresources.NewClient() error = %v", err)
+	}
+	c := &client{
+		resourceClient: func() (*armresources.Client, error) { return resourceClient, nil },
+		subscriptionID: "sub",
+	}
+
+	const concurrency = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for range concurrency {
+		wg.Go(func() {
+			id, err := c.GetKeyVaultID(t.Context(), vaultName, nil)
+			if err == nil && id != testKeyVaultID {
+				err = fmt.Errorf("GetKeyVaultID() = %q, want %q", id, testKeyVaultID)
+			}
+			errs <- err
+		})
+	}
+
+	// Wait for all the goroutines to join the in-flight lookup
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("the key vaults were listed %d times, want 1", got)
+	}
+}
+
+func TestClientGetKeyVaultIDCanceledByFirstCaller(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	fakeServer := armresourcesfake.Server{
+		NewListPager: func(_ *armresources.ClientListOptions) (resp azfake.PagerResponder[armresources.ClientListResponse]) {
+			calls.Add(1)
+			started <- struct{}{}
+			<-release
+			resp.AddPage(http.StatusOK, armresources.ClientListResponse{
+				ResourceListResult: armresources.ResourceListResult{
+					Value: []*armresources.GenericResourceExpanded{{ID: to.Ptr(testKeyVaultID)}},
+				},
+			}, nil)
+			return
+		},
+	}
+	resourceClient, err := armresources.NewClient("sub", &azfake.TokenCredential{}, &arm.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Transport: armresourcesfake.NewServerTransport(&fakeServer),
+		},
+	})
+	if err != nil {
+		t.Fatalf("armresources.NewClient() error = %v", err)
+	}
+	c := &client{
+		resourceClient: func() (*armresources.Client, error) { return resourceClient, nil },
+		subscriptionID: "sub",
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetKeyVaultID(ctx, vaultName, nil)
+		firstErr <- err
+	}()
+	// Wait for the first caller to start the lookup
+	<-started
+
+	type result struct {
+		id  string
+		err error
+	}
+	second := make(chan result, 1)
+	joined := make(chan struct{})
+	go func() {
+		// Key vault names are case-insensitive, so the lookup is shared
+		id, err := c.GetKeyVaultID(&doneNotifyingContext{Context: t.Context(), called: joined}, strings.ToUpper(vaultName), nil)
+		second <- result{id, err}
+	}()
+	// Wait for the second caller to join the lookup
+	<-joined
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetKeyVaultID() error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	got := <-second
+	if got.err != nil {
+		t.Fatalf("GetKeyVaultID() error = %v", got.err)
+	}
+	if got.id != testKeyVaultID {
+		t.Errorf("GetKeyVaultID() = %q, want %q", got.id, testKeyVaultID)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("the key vaults were listed %d times, want 1", got)
+	}
+}
+
+// doneNotifyingContext closes called when Done is called for the first time,
+// which GetKeyVaultID does after joining the lookup.
+type doneNotifyingContext struct {
+	context.Context
+	called chan struct{}
+	once   sync.Once
+}
+
+func (c *doneNotifyingContext) Done() <-chan struct{} {
+	c.once.Do(func() { close(c.called) })
+	return c.Context.Done()
+}
+
+func TestClientGetKeyVaultIDInOtherSubscription(t *testing.T) {
+	t.Parallel()
+
+	otherKeyVaultID := "/subscriptions/other/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/" + vaultName
+
+	tests := []struct {
+		name                   string
+		subscriptionID         string
+		searchAllSubscriptions bool
+		subscriptions          string
+		subscriptionsStatus    int
+		resources              map[string]string
+		want                   string
+		wantErr                string
+	}{
+		{
+			name:                   "found in the configured subscription",
+			subscriptionID:         "sub",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"value":[{"subscriptionId":"sub","state":"Enabled"},{"subscriptionId":"other","state":"Enabled"}]}`,
+			resources: map[string]string{
+				"sub": `{"value":[{"id":"` + testKeyVaultID + `"}]}`,
+			},
+			want: testKeyVaultID,
+		},
+		{
+			name:                   "found in another subscription",
+			subscriptionID:         "sub",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"value":[{"subscriptionId":"sub","state":"Enabled"},{"subscriptionId":"other","state":"Enabled"}]}`,
+			resources: map[string]string{
+				"sub":   `{"value":[]}`,
+				"other": `{"value":[{"id":"` + otherKeyVaultID + `"}]}`,
+			},
+			want: otherKeyVaultID,
+		},
+		{
+			name:                   "no subscription configured",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"value":[{"subscriptionId":"sub","state":"Enabled"},{"subscriptionId":"other","state":"Enabled"}]}`,
+			resources: map[string]string{
+				"sub":   `{"value":[]}`,
+				"other": `{"value":[{"id":"` + otherKeyVaultID + `"}]}`,
+			},
+			want: otherKeyVaultID,
+		},
+		{
+			name:                   "not found in any subscriptions",
+			subscriptionID:         "sub",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"value":[{"subscriptionId":"sub","state":"Enabled"},{"subscriptionId":"other","state":"Enabled"}]}`,
+			resources: map[string]string{
+				"sub":   `{"value":[]}`,
+				"other": `{"value":[]}`,
+			},
+			wantErr: "not found in any subscriptions",
+		},
+		{
+			name:                   "disabled subscriptions are skipped",
+			subscriptionID:         "sub",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"value":[{"subscriptionId":"sub","state":"Enabled"},{"subscriptionId":"other","state":"Disabled"}]}`,
+			resources: map[string]string{
+				"sub": `{"value":[]}`,
+			},
+			wantErr: "not found in any subscriptions",
+		},
+		{
+			name:           "other subscriptions are not searched by default",
+			subscriptionID: "sub",
+			resources: map[string]string{
+				"sub": `{"value":[]}`,
+			},
+			wantErr: "the key vault \"" + vaultName + "\" not found;",
+		},
+		{
+			name:                   "failed to list subscriptions",
+			subscriptionID:         "sub",
+			searchAllSubscriptions: true,
+			subscriptions:          `{"error":{"code":"AuthorizationFailed","message":"forbidden"}}`,
+			subscriptionsStatus:    http.StatusForbidden,
+			resources: map[string]string{
+				"sub": `{"value":[]}`,
+			},
+			wantErr: "AuthorizationFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewClient(tt.subscriptionID, &ClientOptions{
+				Credential: &azfake.TokenCredential{},
+				Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+					status, body := http.StatusOK, tt.subscriptions
+					if req.URL.Path == "/subscriptions" {
+						if tt.subscriptions == "" {
+							t.Errorf("got an unexpected request to %s", req.URL.Path)
+						}
+						if tt.subscriptionsStatus != 0 {
+							status = tt.subscriptionsStatus
+						}
+					} else {
+						subscriptionID, ok := strings.CutSuffix(strings.TrimPrefix(req.URL.Path, "/subscriptions/"), "/resources")
+						if body, ok = tt.resources[subscriptionID]; !ok {
+							t.Errorf("got an unexpected request to %s", req.URL.Path)
+						}
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+						Request:    req,
+					}, nil
+				}),
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			got, err := c.GetKeyVaultID(t.Context(), vaultName, &GetKeyVaultIDOptions{SearchAllSubscriptions: tt.searchAllSubscriptions})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetKeyVaultID() error = %v, want an error containing %q", err, tt.wantErr)
+				}
+				if tt.subscriptionsStatus != 0 && !strings.Contains(err.Error(), "the key vault \""+vaultName+"\" not found;") {
+					t.Errorf("GetKeyVaultID() error = %v, want the not-found error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("GetKeyVaultID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetKeyVaultID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientCheckVaultHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tokenErr     error
+		endpointErr  error
+		listStatus   int
+		wantEndpoint bool
+		wantToken    bool
+		wantList     bool
+	}{
+		{
+			name:         "healthy",
+			listStatus:   http.StatusOK,
+			wantEndpoint: true,
+			wantToken:    true,
+			wantList:     true,
+		},
+		{
+			name:         "forbidden",
+			listStatus:   http.StatusForbidden,
+			wantEndpoint: true,
+			wantToken:    true,
+		},
+		{
+			name:        "unreachable",
+			endpointErr: fmt.Errorf("dial tcp: lookup %s.vault.azure.net: no such host", vaultName),
+			wantToken:   true,
+		},
+		{
+			name:         "no token",
+			tokenErr:     fmt.Errorf("no credentials"),
+			wantEndpoint: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			credential := &azfake.TokenCredential{}
+			if tt.tokenErr != nil {
+				credential.SetError(tt.tokenErr)
+			}
+			c, err := NewClient("sub", &ClientOptions{
+				Credential: credential,
+				Transport: transportFunc(func(req *http.Request) (*http.Response, error) {
+					if tt.endpointErr != nil {
+						return nil, tt.endpointErr
+					}
+					if req.URL.Path == "/secrets" {
+						return &http.Response{
+							StatusCode: tt.listStatus,
+							Header:     http.Header{"Content-Type": []string{"application/json"}},
+							Body:       io.NopCloser(strings.NewReader(`{"value":[]}`)),
+							Request:    req,
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: http.StatusUnauthorized,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader("")),
+						Request:    req,
+					}, nil
+				}),
+			})
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+
+			health := c.CheckVaultHealth(t.Context(), testKeyVaultID)
+			if (health.EndpointError == nil) != tt.wantEndpoint {
+				t.Errorf("EndpointError = %v, want passed: %v", health.EndpointError, tt.wantEndpoint)
+			}
+			if (health.TokenError == nil) != tt.wantToken {
+				t.Errorf("TokenError = %v, want passed: %v", health.TokenError, tt.wantToken)
+			}
+			if (health.ListError == nil) != tt.wantList {
+				t.Errorf("ListError = %v, want passed: %v", health.ListError, tt.wantList)
+			}
+		})
+	}
+}
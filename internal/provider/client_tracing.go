@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const tracerScopeName = "github.com/abicky/terraform-provider-azurekv"
+
+// The tracer providers created by the clients, which are shut down by ShutdownTracing on exit.
+var (
+	tracerProviders      []*sdktrace.TracerProvider
+	tracerProvidersMutex sync.Mutex
+)
+
+// ShutdownTracing exports the spans that have not been exported yet and shuts down the tracer providers.
+// It should be called after the provider server stops.
+func ShutdownTracing(ctx context.Context) error {
+	tracerProvidersMutex.Lock()
+	providers := tracerProviders
+	tracerProviders = nil
+	tracerProvidersMutex.Unlock()
+
+	var errs []error
+	for _, tp := range providers {
+		errs = append(errs, tp.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// newTracerProvider returns the tracer provider whose spans are exported with OTLP/HTTP to the endpoint,
+// or nil if neither the endpoint nor the endpoint environment variables of OpenTelemetry are set.
+// The exporter and the resource respect the other environment variables, such as OTEL_EXPORTER_OTLP_HEADERS and OTEL_SERVICE_NAME.
+func newTracerProvider(ctx context.Context, endpoint string) (*sdktrace.TracerProvider, error) {
+	var options []otlptracehttp.Option
+	if endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpointURL(endpoint))
+	} else if os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return nil, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+
+	tracerProvidersMutex.Lock()
+	tracerProviders = append(tracerProviders, tp)
+	tracerProvidersMutex.Unlock()
+
+	return tp, nil
+}
+
+// secretSpanAttributesPolicy adds the vault name and the secret name to the span of each Key Vault operation,
+// so that the spans can be searched by them.
+type secretSpanAttributesPolicy struct {
+	tracer tracing.Tracer
+}
+
+var _ policy.Policy = (*secretSpanAttributesPolicy)(nil)
+
+func (p *secretSpanAttributesPolicy) Do(req *policy.Request) (*http.Response, error) {
+	span := p.tracer.SpanFromContext(req.Raw().Context())
+
+	if vault, ok := vaultRequestOf(req.Raw()); ok {
+		attrs := []tracing.Attribute{{Key: "azurekv.vault_name", Value: vault.vaultName}}
+		// The path is /secrets/{name}[/{version}] or /deletedsecrets/{name}
+		if segments := vault.pathSegments(req.Raw().URL); len(segments) >= 2 {
+			attrs = append(attrs, tracing.Attribute{Key: "azurekv.secret_name", Value: segments[1]})
+		}
+		span.SetAttributes(attrs...)
+	}
+
+	resp, err := req.Next()
+	if err == nil {
+		span.SetAttributes(tracing.Attribute{Key: "http.response.status_code", Value: resp.StatusCode})
+	}
+	return resp, err
+}
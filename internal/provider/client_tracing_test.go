@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/Azure/azure-sdk-for-go/sdk/tracing/azotel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
+	tp, err := newTracerProvider(context.Background(), "")
+	if err != nil {
+		t.Fatalf("newTracerProvider() error = %v", err)
+	}
+	if tp != nil {
+		t.Errorf("newTracerProvider() = %v, want nil", tp)
+	}
+
+	tp, err = newTracerProvider(context.Background(), "http://localhost:4318/v1/traces")
+	if err != nil {
+		t.Fatalf("newTracerProvider() error = %v", err)
+	}
+	if tp == nil {
+		t.Fatal("newTracerProvider() = nil")
+	}
+	if err := ShutdownTracing(context.Background()); err != nil {
+		t.Errorf("ShutdownTracing() error = %v", err)
+	}
+}
+
+func TestSecretSpanAttributesPolicy(t *testing.T) {
+	t.Parallel()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracingProvider := azotel.NewTracingProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), nil)
+
+	transport := transportFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"SecretNotFound","message":"not found"}}`)),
+			Request:    req,
+		}, nil
+	})
+
+	secretClient, err := azsecrets.NewClient(
+		testVaultURL,
+		&azfake.TokenCredential{},
+		&azsecrets.ClientOptions{
+			ClientOptions: policy.ClientOptions{
+				Transport:       transport,
+				TracingProvider: tracingProvider,
+				PerCallPolicies: []policy.Policy{
+					&requestLogPolicy{vault: testVaultRequest},
+					&secretSpanAttributesPolicy{tracer: tracingProvider.NewTracer(tracerScopeName, "")},
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := secretClient.GetSecret(context.Background(), "secret-name", "", nil); err == nil {
+		t.Fatal("GetSecret() succeeded unexpectedly")
+	}
+
+	spans := exporter.GetSpans()
+	var operation *tracetest.SpanStub
+	for i, s := range spans {
+		if !s.Parent.IsValid() {
+			operation = &spans[i]
+		}
+	}
+	if operation == nil {
+		t.Fatalf("no root span in %v", spans)
+	}
+	if operation.Name != "Client.GetSecret" {
+		t.Errorf("got name %q, want %q", operation.Name, "Client.GetSecret")
+	}
+	if operation.Status.Code != codes.Error {
+		t.Errorf("got status %v, want %v", operation.Status.Code, codes.Error)
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range operation.Attributes {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	for key, want := range map[string]string{
+		"azurekv.vault_name":        "vault-name",
+		"azurekv.secret_name":       "secret-name",
+		"http.response.status_code": "404",
+	} {
+		if attrs[key] != want {
+			t.Errorf("got %s = %v, want %v", key, attrs[key], want)
+		}
+	}
+
+	for _, s := range spans {
+		if s.SpanContext.TraceID() != operation.SpanContext.TraceID() {
+			t.Errorf("span %q has trace ID %s, want %s", s.Name, s.SpanContext.TraceID(), operation.SpanContext.TraceID())
+		}
+	}
+}
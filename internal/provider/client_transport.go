@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// newHTTPClient returns the HTTP client shared by the credential, the ARM client, and the Key Vault clients.
+func newHTTPClient(options ClientOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.ProxyURL != "" {
+		proxyFunc, err := proxyFuncFor(options.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	if len(options.CABundle) > 0 || options.MinTLSVersion != 0 || options.InsecureSkipVerify {
+		tlsConfig, err := newTLSConfig(options.CABundle, options.MinTLSVersion)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.InsecureSkipVerify = options.InsecureSkipVerify
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if options.MaxIdleConns > 0 {
+		// All the requests to a vault go to the same host, so the per-host limit, which defaults to 2, matters more than the total
+		transport.MaxIdleConns = int(options.MaxIdleConns)
+		transport.MaxIdleConnsPerHost = int(options.MaxIdleConns)
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	transport.DisableKeepAlives = options.DisableKeepAlives
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newTLSConfig returns the TLS config trusting the CA certificates in addition to the system ones,
+// so that TLS-inspecting proxies and private PKI can be used while the public endpoints are still verified.
+func newTLSConfig(caBundle []byte, minVersion uint16) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: minVersion,
+	}
+
+	if len(caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("no valid PEM-encoded certificate is found in the CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// proxyFuncFor returns the function that chooses the proxy for a request, which uses the proxy URL for all requests
+// except those to the hosts in NO_PROXY, like http.ProxyFromEnvironment.
+func proxyFuncFor(proxyURL string) (func(*url.URL) (*url.URL, error), error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: the scheme must be http, https, or socks5", proxyURL)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: the host is missing", proxyURL)
+	}
+
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+
+	config := &httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    noProxy,
+	}
+	return config.ProxyFunc(), nil
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestProxyFuncFor(t *testing.T) {
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	tests := []struct {
+		name      string
+		proxyURL  string
+		targetURL string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "proxied",
+			proxyURL:  "http://proxy.example.com:3128",
+			targetURL: "https://vault-name.vault.azure.net/secrets/secret-name",
+			want:      "http://proxy.example.com:3128",
+		},
+		{
+			name:      "excluded by NO_PROXY",
+			proxyURL:  "http://proxy.example.com:3128",
+			targetURL: "https://internal.example.com/",
+		},
+		{
+			name:     "unsupported scheme",
+			proxyURL: "ftp://proxy.example.com",
+			wantErr:  true,
+		},
+		{
+			name:     "missing host",
+			proxyURL: "http://:3128",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxyFunc, err := proxyFuncFor(tt.proxyURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("proxyFuncFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			target, err := url.Parse(tt.targetURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := proxyFunc(target)
+			if err != nil {
+				t.Fatalf("proxyFunc() error = %v", err)
+			}
+			var gotURL string
+			if got != nil {
+				gotURL = got.String()
+			}
+			if gotURL != tt.want {
+				t.Errorf("proxyFunc() = %q, want %q", gotURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	tests := []struct {
+		name       string
+		caBundle   []byte
+		minVersion uint16
+		wantRoots  bool
+		wantErr    bool
+	}{
+		{
+			name:       "min TLS version only",
+			minVersion: tls.VersionTLS13,
+		},
+		{
+			name:      "CA bundle",
+			caBundle:  caBundle,
+			wantRoots: true,
+		},
+		{
+			name:     "invalid CA bundle",
+			caBundle: []byte("not a certificate"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newTLSConfig(tt.caBundle, tt.minVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.MinVersion != tt.minVersion {
+				t.Errorf("MinVersion = %d, want %d", got.MinVersion, tt.minVersion)
+			}
+			if (got.RootCAs != nil) != tt.wantRoots {
+				t.Errorf("RootCAs = %v, want set: %v", got.RootCAs, tt.wantRoots)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	httpClient, err := newHTTPClient(ClientOptions{
+		MaxIdleConns:      10,
+		IdleConnTimeout:   30 * time.Second,
+		DisableKeepAlives: true,
+	})
+	if err != nil {
+		t.Fatalf("newHTTPClient() error = %v", err)
+	}
+
+	transport := httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 10 {
+		t.Errorf("MaxIdleConns = %d and MaxIdleConnsPerHost = %d, want 10", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 30*time.Second {
+		t.Errorf("IdleConnTimeout = %s, want 30s", transport.IdleConnTimeout)
+	}
+	if !transport.DisableKeepAlives {
+		t.Error("DisableKeepAlives = false, want true")
+	}
+
+	httpClient, err = newHTTPClient(ClientOptions{})
+	if err != nil {
+		t.Fatalf("newHTTPClient() error = %v", err)
+	}
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	transport = httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConns != defaultTransport.MaxIdleConns || transport.IdleConnTimeout != defaultTransport.IdleConnTimeout || transport.DisableKeepAlives {
+		t.Error("the defaults of net/http are not kept")
+	}
+}
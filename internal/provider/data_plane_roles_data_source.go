@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*DataPlaneRolesDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*DataPlaneRolesDataSource)(nil)
+
+func NewDataPlaneRolesDataSource() datasource.DataSource {
+	return &DataPlaneRolesDataSource{}
+}
+
+// DataPlaneRolesDataSource defines the data source implementation.
+type DataPlaneRolesDataSource struct {
+	client            Client
+	defaultKeyVaultID string
+}
+
+type DataPlaneRolesDataSourceModel struct {
+	KeyVaultID      types.String `tfsdk:"key_vault_id"`
+	ID              types.String `tfsdk:"id"`
+	PrincipalID     types.String `tfsdk:"principal_id"`
+	RoleNames       types.Set    `tfsdk:"role_names"`
+	RoleAssignments types.List   `tfsdk:"role_assignments"`
+}
+
+// DataPlaneRoleAssignmentModel describes a role assignment in the role_assignments attribute.
+type DataPlaneRoleAssignmentModel struct {
+	ID               types.String `tfsdk:"id"`
+	RoleName         types.String `tfsdk:"role_name"`
+	RoleDefinitionID types.String `tfsdk:"role_definition_id"`
+	Scope            types.String `tfsdk:"scope"`
+}
+
+var dataPlaneRoleAssignmentAttrTypes = map[string]attr.Type{
+	"id":                 types.StringType,
+	"role_name":          types.StringType,
+	"role_definition_id": types.StringType,
+	"scope":              types.StringType,
+}
+
+// setRoleAssignments sets the role assignments, in which role_names only includes the roles that apply to the whole key vault.
+func (m *DataPlaneRolesDataSourceModel) setRoleAssignments(ctx context.Context, roleAssignments *DataPlaneRoleAssignments) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.PrincipalID = types.StringValue(roleAssignments.PrincipalID)
+
+	roleNames := []string{}
+	assignments := make([]DataPlaneRoleAssignmentModel, 0, len(roleAssignments.Assignments))
+	for _, a := range roleAssignments.Assignments {
+		if a.coversKeyVault(m.KeyVaultID.ValueString()) && !slices.Contains(roleNames, a.RoleName) {
+			roleNames = append(roleNames, a.RoleName)
+		}
+		assignments = append(assignments, DataPlaneRoleAssignmentModel{
+			ID:               types.StringValue(a.ID),
+			RoleName:         types.StringValue(a.RoleName),
+			RoleDefinitionID: types.StringValue(a.RoleDefinitionID),
+			Scope:            types.StringValue(a.Scope),
+		})
+	}
+
+	m.RoleNames, diags = types.SetValueFrom(ctx, types.StringType, roleNames)
+	if diags.HasError() {
+		return diags
+	}
+	m.RoleAssignments, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dataPlaneRoleAssignmentAttrTypes}, assignments)
+	return diags
+}
+
+func (d *DataPlaneRolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_data_plane_roles"
+}
+
+func (d *DataPlaneRolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to access the built-in Key Vault data-plane roles, such as Key Vault Secrets Officer, " +
+			"that the principal of the provider holds on a Key Vault, so that modules can assert least-privilege setups before attempting writes.\n\n" +
+			"The role assignments to the groups of the principal are included, but custom roles and access policies are not. " +
+			"This requires the `Microsoft.Authorization/roleAssignments/read` permission, which the Reader and Key Vault Reader roles include.",
+
+		Attributes: map[string]schema.Attribute{
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"If not specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault.",
+				Computed:            true,
+			},
+			"principal_id": schema.StringAttribute{
+				MarkdownDescription: "The object ID of the principal of the provider.",
+				Computed:            true,
+			},
+			"role_names": schema.SetAttribute{
+				MarkdownDescription: "The names of the roles that apply to the whole Key Vault, i.e. those assigned at the scope of the Key Vault or above it.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"role_assignments": schema.ListNestedAttribute{
+				MarkdownDescription: "The assignments of the roles, including those scoped to individual objects in the Key Vault.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the role assignment.",
+							Computed:            true,
+						},
+						"role_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the role, such as `Key Vault Secrets User`.",
+							Computed:            true,
+						},
+						"role_definition_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the role definition.",
+							Computed:            true,
+						},
+						"scope": schema.StringAttribute{
+							MarkdownDescription: "The scope of the role assignment, such as a subscription, the Key Vault, or a secret in it.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataPlaneRolesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
+}
+
+func (d *DataPlaneRolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model DataPlaneRolesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyVaultID.IsNull() {
+		if d.defaultKeyVaultID == "" {
+			resp.Diagnostics.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		model.KeyVaultID = types.StringValue(d.defaultKeyVaultID)
+	}
+	model.ID = model.KeyVaultID
+
+	roleAssignments, err := d.client.ListDataPlaneRoleAssignments(ctx, model.KeyVaultID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to List Role Assignments", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(model.setRoleAssignments(ctx, roleAssignments)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataPlaneRolesDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: dataPlaneRolesDataSourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.azurekv_data_plane_roles.test", "principal_id", "data.azurerm_client_config.principal", "object_id"),
+					resource.TestCheckResourceAttrSet("data.azurekv_data_plane_roles.test", "role_names.#"),
+				),
+			},
+		},
+	})
+}
+
+func dataPlaneRolesDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+data "azurerm_client_config" "principal" {}
+
+data "azurekv_data_plane_roles" "test" {
+  key_vault_id = local.key_vault_id
+}
+`, providersConfig(resourceSuffix))
+}
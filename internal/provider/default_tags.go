@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The limits of tags on Key Vault objects.
+// cf. https://learn.microsoft.com/en-us/azure/key-vault/general/about-keys-secrets-certificates#object-types
+const (
+	maxTags           = 15
+	maxTagKeyLength   = 512
+	maxTagValueLength = 256
+)
+
+// mergeDefaultTags returns the default tags overridden by the tags.
+func mergeDefaultTags(defaultTags map[string]string, tags map[string]*string) map[string]*string {
+	merged := make(map[string]*string, len(defaultTags)+len(tags))
+	for k, v := range defaultTags {
+		merged[k] = &v
+	}
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// copiedSecretTags returns the tags of a secret copied from the source tags, overriding the default tags.
+// The checksum tag is dropped because the copied value isn't verified against it.
+func copiedSecretTags(defaultTags map[string]string, sourceTags map[string]*string) map[string]*string {
+	tags := maps.Clone(sourceTags)
+	delete(tags, valueChecksumTagName)
+	return mergeDefaultTags(defaultTags, tags)
+}
+
+// splitDefaultTags sets all the tags of the secret to tags_all and removes from tags the default tags
+// unless they are specified in managedTags, which are the tags specified in the configuration.
+func splitDefaultTags(ctx context.Context, model *SecretResourceModel, managedTags map[string]*string, defaultTags map[string]string) diag.Diagnostics {
+	model.TagsAll = model.Tags
+
+	var diags diag.Diagnostics
+	model.Tags, diags = removeDefaultTags(ctx, model.TagsAll, managedTags, defaultTags)
+	return diags
+}
+
+// removeDefaultTags returns tagsAll without the default tags unless they are specified in managedTags.
+func removeDefaultTags(ctx context.Context, tagsAll types.Map, managedTags map[string]*string, defaultTags map[string]string) (types.Map, diag.Diagnostics) {
+	tags, diags := toMap(tagsAll)
+	if diags.HasError() {
+		return tagsAll, diags
+	}
+
+	for k, v := range defaultTags {
+		if _, ok := managedTags[k]; ok {
+			continue
+		}
+		if tag, ok := tags[k]; ok && tag != nil && *tag == v {
+			delete(tags, k)
+		}
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, tags)
+}
+
+// modifyTagsAllPlan plans tags_all by merging the default tags of the provider into the planned tags
+// and validates that tags_all has the required tags of the provider. If storesChecksumTag is true,
+// the limits of Key Vault are validated including the checksum tag.
+func modifyTagsAllPlan(ctx context.Context, resp *resource.ModifyPlanResponse, defaultTags map[string]string, requiredTags []string, storesChecksumTag bool) {
+	var planTags types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("tags"), &planTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planTags.IsUnknown() {
+		resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))
+		return
+	}
+
+	tags, diags := toMap(planTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	merged := mergeDefaultTags(defaultTags, tags)
+	if missing := missingRequiredTags(requiredTags, merged); len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags"),
+			"Missing Required Tags",
+			fmt.Sprintf("The secret must have the tags required by the provider, but the following tags are missing: %s", strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	stored := maps.Clone(merged)
+	if storesChecksumTag {
+		stored[valueChecksumTagName] = to.Ptr("")
+	}
+	if violations := tagLimitViolations(stored); len(violations) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags"),
+			"Too Many or Too Long Tags",
+			fmt.Sprintf("The tags of the secret including the default tags exceed the limits of Key Vault: %s", strings.Join(violations, "; ")),
+		)
+		return
+	}
+
+	tagsAll, diags := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// missingRequiredTags returns the required tag keys that the tags lack.
+func missingRequiredTags(requiredTags []string, tags map[string]*string) []string {
+	var missing []string
+	for _, k := range requiredTags {
+		if _, ok := tags[k]; !ok && !slices.Contains(missing, k) {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
+
+// tagLimitViolations returns the descriptions of the tags exceeding the limits of Key Vault objects.
+func tagLimitViolations(tags map[string]*string) []string {
+	var violations []string
+	if len(tags) > maxTags {
+		violations = append(violations, fmt.Sprintf("the number of tags is %d, which exceeds %d", len(tags), maxTags))
+	}
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		if n := utf8.RuneCountInString(k); n > maxTagKeyLength {
+			violations = append(violations, fmt.Sprintf("the key %q has %d characters, which exceeds %d", k, n, maxTagKeyLength))
+		}
+		if v := tags[k]; v != nil {
+			if n := utf8.RuneCountInString(*v); n > maxTagValueLength {
+				violations = append(violations, fmt.Sprintf("the value of %q has %d characters, which exceeds %d", k, n, maxTagValueLength))
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	got := mergeDefaultTags(
+		map[string]string{"owner": "platform", "cost_center": "1234"},
+		map[string]*string{"owner": to.Ptr("app"), "environment": to.Ptr("test")},
+	)
+	want := map[string]*string{
+		"owner":       to.Ptr("app"),
+		"cost_center": to.Ptr("1234"),
+		"environment": to.Ptr("test"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeDefaultTags() = %v, want %v", got, want)
+	}
+}
+
+func TestCopiedSecretTags(t *testing.T) {
+	t.Parallel()
+
+	got := copiedSecretTags(
+		map[string]string{"owner": "platform", "managed-by": "terraform"},
+		map[string]*string{"owner": to.Ptr("app"), valueChecksumTagName: to.Ptr("checksum")},
+	)
+	want := map[string]*string{
+		"owner":      to.Ptr("app"),
+		"managed-by": to.Ptr("terraform"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("copiedSecretTags() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	defaultTags := map[string]string{"owner": "platform", "cost_center": "1234"}
+
+	tests := []struct {
+		name        string
+		tags        map[string]attr.Value
+		managedTags map[string]*string
+		wantTags    map[string]attr.Value
+	}{
+		{
+			name: "default tags",
+			tags: map[string]attr.Value{
+				"owner":       types.StringValue("platform"),
+				"cost_center": types.StringValue("1234"),
+				"environment": types.StringValue("test"),
+			},
+			managedTags: map[string]*string{"environment": to.Ptr("test")},
+			wantTags: map[string]attr.Value{
+				"environment": types.StringValue("test"),
+			},
+		},
+		{
+			name: "default tags overridden",
+			tags: map[string]attr.Value{
+				"owner":       types.StringValue("app"),
+				"cost_center": types.StringValue("1234"),
+			},
+			managedTags: map[string]*string{"owner": to.Ptr("app")},
+			wantTags: map[string]attr.Value{
+				"owner": types.StringValue("app"),
+			},
+		},
+		{
+			name: "default tags specified in the configuration",
+			tags: map[string]attr.Value{
+				"owner":       types.StringValue("platform"),
+				"cost_center": types.StringValue("1234"),
+			},
+			managedTags: map[string]*string{"owner": to.Ptr("platform")},
+			wantTags: map[string]attr.Value{
+				"owner": types.StringValue("platform"),
+			},
+		},
+		{
+			name: "default tags changed outside of Terraform",
+			tags: map[string]attr.Value{
+				"owner":       types.StringValue("someone"),
+				"cost_center": types.StringValue("1234"),
+			},
+			wantTags: map[string]attr.Value{
+				"owner": types.StringValue("someone"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var model SecretResourceModel
+			model.Tags = types.MapValueMust(types.StringType, tt.tags)
+
+			if diags := splitDefaultTags(t.Context(), &model, tt.managedTags, defaultTags); diags.HasError() {
+				t.Fatalf("splitDefaultTags() diags = %v", diags)
+			}
+
+			if want := types.MapValueMust(types.StringType, tt.tags); !model.TagsAll.Equal(want) {
+				t.Errorf("tags_all = %v, want %v", model.TagsAll, want)
+			}
+			if want := types.MapValueMust(types.StringType, tt.wantTags); !model.Tags.Equal(want) {
+				t.Errorf("tags = %v, want %v", model.Tags, want)
+			}
+		})
+	}
+}
+
+func TestMissingRequiredTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		requiredTags []string
+		tags         map[string]*string
+		want         []string
+	}{
+		{
+			name:         "no required tags",
+			requiredTags: nil,
+			tags:         map[string]*string{"owner": to.Ptr("platform")},
+		},
+		{
+			name:         "all the required tags",
+			requiredTags: []string{"owner", "env"},
+			tags:         map[string]*string{"owner": to.Ptr("platform"), "env": to.Ptr("test"), "cost_center": to.Ptr("1234")},
+		},
+		{
+			name:         "missing tags",
+			requiredTags: []string{"owner", "env", "cost_center", "env"},
+			tags:         map[string]*string{"owner": to.Ptr("platform")},
+			want:         []string{"env", "cost_center"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := missingRequiredTags(tt.requiredTags, tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingRequiredTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagLimitViolations(t *testing.T) {
+	t.Parallel()
+
+	tooManyTags := make(map[string]*string, 16)
+	for i := range 16 {
+		tooManyTags[fmt.Sprintf("key%02d", i)] = to.Ptr("value")
+	}
+
+	tests := []struct {
+		name string
+		tags map[string]*string
+		want []string
+	}{
+		{
+			name: "within the limits",
+			tags: map[string]*string{strings.Repeat("k", 512): to.Ptr(strings.Repeat("v", 256)), "empty": nil},
+		},
+		{
+			name: "too many tags",
+			tags: tooManyTags,
+			want: []string{"the number of tags is 16, which exceeds 15"},
+		},
+		{
+			name: "too long key and value",
+			tags: map[string]*string{strings.Repeat("k", 513): to.Ptr("value"), "owner": to.Ptr(strings.Repeat("v", 257))},
+			want: []string{
+				fmt.Sprintf("the key %q has 513 characters, which exceeds 512", strings.Repeat("k", 513)),
+				`the value of "owner" has 257 characters, which exceeds 256`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tagLimitViolations(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagLimitViolations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*DeletedSecretDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*DeletedSecretDataSource)(nil)
+
+func NewDeletedSecretDataSource() datasource.DataSource {
+	return &DeletedSecretDataSource{}
+}
+
+// DeletedSecretDataSource defines the data source implementation.
+type DeletedSecretDataSource struct {
+	client            Client
+	defaultKeyVaultID string
+}
+
+type DeletedSecretDataSourceModel struct {
+	Name               types.String      `tfsdk:"name"`
+	KeyVaultID         types.String      `tfsdk:"key_vault_id"`
+	ID                 types.String      `tfsdk:"id"`
+	Recoverable        types.Bool        `tfsdk:"recoverable"`
+	RecoveryID         types.String      `tfsdk:"recovery_id"`
+	DeletedDate        timetypes.RFC3339 `tfsdk:"deleted_date"`
+	ScheduledPurgeDate timetypes.RFC3339 `tfsdk:"scheduled_purge_date"`
+}
+
+// setDeletedSecret sets the attributes from the deleted secret, or marks the secret as unrecoverable if it is nil.
+func (m *DeletedSecretDataSourceModel) setDeletedSecret(deletedSecret *azsecrets.DeletedSecret) {
+	m.Recoverable = types.BoolValue(deletedSecret != nil)
+	m.RecoveryID = types.StringNull()
+	m.DeletedDate = timetypes.NewRFC3339Null()
+	m.ScheduledPurgeDate = timetypes.NewRFC3339Null()
+	if deletedSecret == nil {
+		return
+	}
+
+	m.RecoveryID = types.StringPointerValue(deletedSecret.RecoveryID)
+	if deletedSecret.DeletedDate != nil {
+		m.DeletedDate = timetypes.NewRFC3339TimeValue(deletedSecret.DeletedDate.UTC())
+	}
+	if deletedSecret.ScheduledPurgeDate != nil {
+		m.ScheduledPurgeDate = timetypes.NewRFC3339TimeValue(deletedSecret.ScheduledPurgeDate.UTC())
+	}
+}
+
+func (d *DeletedSecretDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deleted_secret"
+}
+
+func (d *DeletedSecretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to access information about a soft-deleted Key Vault Secret, " +
+			"so that recovery runbooks can branch on whether the secret is still recoverable.\n\n" +
+			"The data source doesn't fail if the secret is not soft-deleted, e.g. if it has been purged or has never been deleted, but `recoverable` is `false`.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the deleted Key Vault Secret.",
+				Required:            true,
+			},
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault in which the secret was deleted, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"If not specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the deleted Key Vault Secret in the form of `{key_vault_id}/deletedSecrets/{name}`.",
+				Computed:            true,
+			},
+			"recoverable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the secret is soft-deleted and can be recovered.",
+				Computed:            true,
+			},
+			"recovery_id": schema.StringAttribute{
+				MarkdownDescription: "The URL to recover the deleted Key Vault Secret, such as `https://example.vault.azure.net/deletedsecrets/secret-name`.",
+				Computed:            true,
+			},
+			"deleted_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the Key Vault Secret was deleted.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"scheduled_purge_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the deleted Key Vault Secret is purged and can no longer be recovered.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+		},
+	}
+}
+
+func (d *DeletedSecretDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
+}
+
+func (d *DeletedSecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model DeletedSecretDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyVaultID.IsNull() {
+		if d.defaultKeyVaultID == "" {
+			resp.Diagnostics.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		model.KeyVaultID = types.StringValue(d.defaultKeyVaultID)
+	}
+	model.ID = types.StringValue(model.KeyVaultID.ValueString() + "/deletedSecrets/" + model.Name.ValueString())
+
+	deletedResp, err := d.client.GetDeletedSecret(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), nil)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError("Failed to Get Deleted Secret", err.Error()+azureErrorHint(err, dataActionReadDeleted))
+		return
+	}
+	if err != nil {
+		model.setDeletedSecret(nil)
+	} else {
+		model.setDeletedSecret(&deletedResp.DeletedSecret)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeletedSecretDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: deletedSecretDataSourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurekv_deleted_secret.test", "recoverable", "false"),
+					resource.TestCheckNoResourceAttr("data.azurekv_deleted_secret.test", "recovery_id"),
+					resource.TestCheckNoResourceAttr("data.azurekv_deleted_secret.test", "deleted_date"),
+				),
+			},
+		},
+	})
+}
+
+func deletedSecretDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+data "azurekv_deleted_secret" "test" {
+  name         = "never-deleted-%s"
+  key_vault_id = local.key_vault_id
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/google/uuid"
+)
+
+const (
+	eventGridAPIVersion = "2018-01-01"
+	eventGridScope      = "https://eventgrid.azure.net/.default"
+)
+
+// The types of the events published to the Event Grid topic.
+const (
+	eventTypeSecretNewVersionCreated = "Azurekv.SecretNewVersionCreated"
+	eventTypeSecretDeleted           = "Azurekv.SecretDeleted"
+)
+
+// eventGridPublisher publishes events in the Event Grid schema to a custom topic,
+// so that event-driven pipelines can reload secrets written by Terraform. Secret values are never published.
+type eventGridPublisher struct {
+	endpoint string
+	key      string
+	pipeline runtime.Pipeline
+	// credential is used to authenticate with Microsoft Entra ID if key is empty
+	credential func() (azcore.TokenCredential, error)
+}
+
+// eventGridEvent is an event in the Event Grid schema.
+type eventGridEvent struct {
+	ID          string             `json:"id"`
+	EventType   string             `json:"eventType"`
+	Subject     string             `json:"subject"`
+	EventTime   string             `json:"eventTime"`
+	Data        eventGridEventData `json:"data"`
+	DataVersion string             `json:"dataVersion"`
+}
+
+type eventGridEventData struct {
+	KeyVaultID string `json:"key_vault_id"`
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+}
+
+// newEventGridPublisher returns nil if endpoint is empty, which means no events.
+func newEventGridPublisher(endpoint, key string, pipeline runtime.Pipeline, credential func() (azcore.TokenCredential, error)) *eventGridPublisher {
+	if endpoint == "" {
+		return nil
+	}
+
+	return &eventGridPublisher{
+		endpoint:   endpoint,
+		key:        key,
+		pipeline:   pipeline,
+		credential: credential,
+	}
+}
+
+// publish publishes the event, and returns an error if the topic doesn't accept it.
+func (p *eventGridPublisher) publish(ctx context.Context, eventType, keyVaultID, name, version string) error {
+	if p == nil {
+		return nil
+	}
+
+	// The endpoint may already have a query, e.g. "https://topic.westus2-1.eventgrid.azure.net/api/events?api-version=2018-01-01"
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("api-version", eventGridAPIVersion)
+	u.RawQuery = query.Encode()
+
+	events := []eventGridEvent{{
+		ID:        uuid.NewString(),
+		EventType: eventType,
+		Subject:   keyVaultID + "/secrets/" + name,
+		EventTime: time.Now().UTC().Format(time.RFC3339Nano),
+		Data: eventGridEventData{
+			KeyVaultID: keyVaultID,
+			Name:       name,
+			Version:    version,
+		},
+		DataVersion: "1",
+	}}
+
+	header := http.Header{}
+	if p.key != "" {
+		header.Set("aeg-sas-key", p.key)
+	} else {
+		cred, err := p.credential()
+		if err != nil {
+			return err
+		}
+		token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{eventGridScope}})
+		if err != nil {
+			return err
+		}
+		header.Set("Authorization", "Bearer "+token.Token)
+	}
+
+	return postJSON(ctx, p.pipeline, u.String(), header, events)
+}
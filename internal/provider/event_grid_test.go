@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestEventGridPublisherPublish(t *testing.T) {
+	t.Parallel()
+
+	credential := func() (azcore.TokenCredential, error) {
+		return &staticTokenCredential{token: "token"}, nil
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		query      string
+		statusCode int
+		wantHeader string
+		wantValue  string
+		wantErr    bool
+	}{
+		{
+			name:       "with the access key",
+			key:        "key",
+			statusCode: http.StatusOK,
+			wantHeader: "aeg-sas-key",
+			wantValue:  "key",
+		},
+		{
+			name:       "with the credential",
+			statusCode: http.StatusOK,
+			wantHeader: "Authorization",
+			wantValue:  "Bearer token",
+		},
+		{
+			name:       "with the api-version in the endpoint",
+			key:        "key",
+			query:      "?api-version=" + eventGridAPIVersion,
+			statusCode: http.StatusOK,
+			wantHeader: "aeg-sas-key",
+			wantValue:  "key",
+		},
+		{
+			name:       "failure",
+			key:        "key",
+			statusCode: http.StatusUnauthorized,
+			wantHeader: "aeg-sas-key",
+			wantValue:  "key",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []eventGridEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query()["api-version"]; len(got) != 1 || got[0] != eventGridAPIVersion {
+					t.Errorf("got api-version %q, want %q", got, eventGridAPIVersion)
+				}
+				if v := r.Header.Get(tt.wantHeader); v != tt.wantValue {
+					t.Errorf("got %s %q, want %q", tt.wantHeader, v, tt.wantValue)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode the body: %v", err)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			publisher := newEventGridPublisher(server.URL+"/api/events"+tt.query, tt.key, newNotificationPipeline(&arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: server.Client()}}), credential)
+			err := publisher.publish(context.Background(), eventTypeSecretNewVersionCreated, testKeyVaultID, "secret-name", "version")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != 1 {
+				t.Fatalf("got %d events, want 1", len(got))
+			}
+			event := got[0]
+			if !regexp.MustCompile(`\A[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}\z`).MatchString(event.ID) {
+				t.Errorf("ID = %q, want a UUID version 4", event.ID)
+			}
+			if event.EventType != eventTypeSecretNewVersionCreated {
+				t.Errorf("EventType = %q, want %q", event.EventType, eventTypeSecretNewVersionCreated)
+			}
+			if want := testKeyVaultID + "/secrets/secret-name"; event.Subject != want {
+				t.Errorf("Subject = %q, want %q", event.Subject, want)
+			}
+			wantData := eventGridEventData{KeyVaultID: testKeyVaultID, Name: "secret-name", Version: "version"}
+			if event.Data != wantData {
+				t.Errorf("Data = %+v, want %+v", event.Data, wantData)
+			}
+		})
+	}
+}
+
+func TestEventGridPublisherDisabled(t *testing.T) {
+	t.Parallel()
+
+	publisher := newEventGridPublisher("", "", runtime.Pipeline{}, nil)
+	if publisher != nil {
+		t.Fatalf("newEventGridPublisher() = %v, want nil", publisher)
+	}
+	if err := publisher.publish(context.Background(), eventTypeSecretDeleted, testKeyVaultID, "secret-name", ""); err != nil {
+		t.Errorf("publish() error = %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*FailoverSecretDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*FailoverSecretDataSource)(nil)
+
+func NewFailoverSecretDataSource() datasource.DataSource {
+	return &FailoverSecretDataSource{}
+}
+
+// FailoverSecretDataSource defines the data source implementation.
+type FailoverSecretDataSource struct {
+	client Client
+}
+
+type FailoverSecretDataSourceModel struct {
+	Name                  types.String      `tfsdk:"name"`
+	KeyVaultIDs           types.List        `tfsdk:"key_vault_ids"`
+	KeyVaultID            types.String      `tfsdk:"key_vault_id"`
+	ID                    types.String      `tfsdk:"id"`
+	VersionlessID         types.String      `tfsdk:"versionless_id"`
+	ContentType           types.String      `tfsdk:"content_type"`
+	NotBeforeDate         timetypes.RFC3339 `tfsdk:"not_before_date"`
+	ExpirationDate        timetypes.RFC3339 `tfsdk:"expiration_date"`
+	Version               types.String      `tfsdk:"version"`
+	ResourceID            types.String      `tfsdk:"resource_id"`
+	ResourceVersionlessID types.String      `tfsdk:"resource_versionless_id"`
+	Tags                  types.Map         `tfsdk:"tags"`
+}
+
+var _ SecretModel = (*FailoverSecretDataSourceModel)(nil)
+
+func (s *FailoverSecretDataSourceModel) GetKeyVaultID() string {
+	return s.KeyVaultID.ValueString()
+}
+
+func (s *FailoverSecretDataSourceModel) SetID(id types.String) {
+	s.ID = id
+}
+
+func (s *FailoverSecretDataSourceModel) SetVersionlessID(id types.String) {
+	s.VersionlessID = id
+}
+
+func (s *FailoverSecretDataSourceModel) SetVersion(version types.String) {
+	s.Version = version
+}
+
+func (s *FailoverSecretDataSourceModel) SetResourceVersionlessID(id types.String) {
+	s.ResourceVersionlessID = id
+}
+
+func (s *FailoverSecretDataSourceModel) SetResourceID(id types.String) {
+	s.ResourceID = id
+}
+
+func (s *FailoverSecretDataSourceModel) SetContentType(contentType types.String) {
+	s.ContentType = contentType
+}
+
+func (s *FailoverSecretDataSourceModel) SetNotBeforeDate(date timetypes.RFC3339) {
+	s.NotBeforeDate = date
+}
+
+func (s *FailoverSecretDataSourceModel) SetExpirationDate(date timetypes.RFC3339) {
+	s.ExpirationDate = date
+}
+
+func (s *FailoverSecretDataSourceModel) SetTags(tags types.Map) {
+	s.Tags = tags
+}
+
+func (d *FailoverSecretDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_failover_secret"
+}
+
+func (d *FailoverSecretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to access information about a Key Vault Secret excluding the secret value " +
+			"from the first of the Key Vaults in which the secret exists, so that active/passive Key Vaults can be switched during a regional failover.\n\n" +
+			"A Key Vault is skipped if the secret doesn't exist in it or if the Key Vault can't be resolved or connected. Any other error fails the data source.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Secret.",
+				Required:            true,
+			},
+			"key_vault_ids": schema.ListAttribute{
+				MarkdownDescription: "Specifies the IDs of the Key Vaults in order of preference, such as `[azurerm_key_vault.primary.id, azurerm_key_vault.secondary.id]`.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(keyVaultIDRegex, "")),
+				},
+			},
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault from which the secret was read.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Key Vault Secret ID.",
+				Computed:            true,
+			},
+			"versionless_id": schema.StringAttribute{
+				MarkdownDescription: "The Versionless ID of the Key Vault Secret. This can be used to always get latest secret value, and enable fetching automatically rotating secrets.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The current version of the Key Vault Secret.",
+				Computed:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "The content type for the Key Vault Secret.",
+				Computed:            true,
+			},
+			"not_before_date": schema.StringAttribute{
+				MarkdownDescription: "The earliest date at which the Key Vault Secret can be used.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"expiration_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the Key Vault Secret expires and is no longer valid.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"resource_id": schema.StringAttribute{
+				MarkdownDescription: "The (Versioned) ID for this Key Vault Secret. This property points to a specific version of a Key Vault Secret, as such using this won't auto-rotate values if used in other Azure Services.",
+				Computed:            true,
+			},
+			"resource_versionless_id": schema.StringAttribute{
+				MarkdownDescription: "The Versionless ID of the Key Vault Secret. This property allows other Azure Services (that support it) to auto-rotate their value when the Key Vault Secret is updated.",
+				Computed:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Any tags assigned to this resource.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *FailoverSecretDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *FailoverSecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model FailoverSecretDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keyVaultIDs []string
+	resp.Diagnostics.Append(model.KeyVaultIDs.ElementsAs(ctx, &keyVaultIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, keyVaultID := range keyVaultIDs {
+		secretProperties, err := d.client.GetSecretProperties(ctx, keyVaultID, model.Name.ValueString(), "", nil)
+		if err != nil {
+			if isSecretUnavailableError(err) {
+				tflog.Warn(ctx, "Skipped the key vault since the secret is unavailable in it", map[string]any{"key_vault_id": keyVaultID, "error": err.Error()})
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Failed to Get Secret Properties",
+				fmt.Sprintf("An unexpected error occurred while reading the secret in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionReadMetadata),
+			)
+			return
+		}
+
+		model.KeyVaultID = types.StringValue(keyVaultID)
+		resp.Diagnostics.Append(setSecretData(&model, secretProperties.ID, secretProperties.Attributes, secretProperties.ContentType, secretProperties.Tags)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Secret Not Found",
+		fmt.Sprintf("The secret %q was not found in any of the key vaults, or the key vaults can't be resolved or connected.", model.Name.ValueString()),
+	)
+}
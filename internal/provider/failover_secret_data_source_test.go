@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFailoverSecretDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: failoverSecretDataSourceConfig(rn),
+				Check: testCheckResourceAttrPairs("data.azurekv_failover_secret.test", "azurerm_key_vault_secret.test", []string{
+					"content_type",
+					"expiration_date",
+					"id",
+					"key_vault_id",
+					"name",
+					"not_before_date",
+					"resource_id",
+					"resource_versionless_id",
+					"tags",
+					"version",
+					"versionless_id",
+				}),
+			},
+		},
+	})
+}
+
+func failoverSecretDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = "secret-value"
+}
+
+data "azurekv_failover_secret" "test" {
+  name = azurerm_key_vault_secret.test.name
+  key_vault_ids = [
+    # The key vault doesn't exist, so it is skipped
+    replace(local.key_vault_id, "/[^/]+$/", "/nx%s"),
+    azurerm_key_vault_secret.test.key_vault_id,
+  ]
+}
+`, providersConfig(resourceSuffix), resourceSuffix, resourceSuffix[:20])
+}
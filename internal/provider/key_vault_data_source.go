@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*KeyVaultDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*KeyVaultDataSource)(nil)
+
+func NewKeyVaultDataSource() datasource.DataSource {
+	return &KeyVaultDataSource{}
+}
+
+// KeyVaultDataSource defines the data source implementation.
+type KeyVaultDataSource struct {
+	client            Client
+	defaultKeyVaultID string
+}
+
+type KeyVaultDataSourceModel struct {
+	KeyVaultID                 types.String `tfsdk:"key_vault_id"`
+	ID                         types.String `tfsdk:"id"`
+	Name                       types.String `tfsdk:"name"`
+	Location                   types.String `tfsdk:"location"`
+	VaultURI                   types.String `tfsdk:"vault_uri"`
+	SoftDeleteRetentionDays    types.Int32  `tfsdk:"soft_delete_retention_days"`
+	PurgeProtectionEnabled     types.Bool   `tfsdk:"purge_protection_enabled"`
+	RBACAuthorizationEnabled   types.Bool   `tfsdk:"rbac_authorization_enabled"`
+	PublicNetworkAccessEnabled types.Bool   `tfsdk:"public_network_access_enabled"`
+	NetworkACLs                types.Object `tfsdk:"network_acls"`
+}
+
+// KeyVaultNetworkACLsModel describes the network_acls attribute.
+type KeyVaultNetworkACLsModel struct {
+	DefaultAction           types.String `tfsdk:"default_action"`
+	Bypass                  types.String `tfsdk:"bypass"`
+	IPRules                 types.Set    `tfsdk:"ip_rules"`
+	VirtualNetworkSubnetIDs types.Set    `tfsdk:"virtual_network_subnet_ids"`
+}
+
+var keyVaultNetworkACLsAttrTypes = map[string]attr.Type{
+	"default_action":             types.StringType,
+	"bypass":                     types.StringType,
+	"ip_rules":                   types.SetType{ElemType: types.StringType},
+	"virtual_network_subnet_ids": types.SetType{ElemType: types.StringType},
+}
+
+func (m *KeyVaultDataSourceModel) setKeyVault(ctx context.Context, kv *KeyVault) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Name = types.StringValue(kv.Name)
+	m.Location = types.StringValue(kv.Location)
+	m.VaultURI = types.StringValue(kv.VaultURI)
+	m.SoftDeleteRetentionDays = types.Int32Value(kv.SoftDeleteRetentionDays)
+	m.PurgeProtectionEnabled = types.BoolValue(kv.PurgeProtectionEnabled)
+	m.RBACAuthorizationEnabled = types.BoolValue(kv.RBACAuthorizationEnabled)
+	// publicNetworkAccess is "Enabled" or "Disabled"
+	m.PublicNetworkAccessEnabled = types.BoolValue(kv.PublicNetworkAccess != "Disabled")
+
+	ipRules, d := types.SetValueFrom(ctx, types.StringType, kv.NetworkACLs.IPRules)
+	diags.Append(d...)
+	subnetIDs, d := types.SetValueFrom(ctx, types.StringType, kv.NetworkACLs.VirtualNetworkSubnetIDs)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	m.NetworkACLs, d = types.ObjectValueFrom(ctx, keyVaultNetworkACLsAttrTypes, KeyVaultNetworkACLsModel{
+		DefaultAction:           types.StringValue(kv.NetworkACLs.DefaultAction),
+		Bypass:                  types.StringValue(kv.NetworkACLs.Bypass),
+		IPRules:                 ipRules,
+		VirtualNetworkSubnetIDs: subnetIDs,
+	})
+	diags.Append(d...)
+	return diags
+}
+
+func (d *KeyVaultDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key_vault"
+}
+
+func (d *KeyVaultDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to access the properties of a Key Vault that matter to the secrets in it, " +
+			"such as soft delete, purge protection, the authorization mode, and the network rules, so that modules can guard against writing secrets into non-compliant Key Vaults.\n\n" +
+			"This requires the `Microsoft.KeyVault/vaults/read` permission, which the Reader and Key Vault Reader roles include.",
+
+		Attributes: map[string]schema.Attribute{
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"If not specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Key Vault.",
+				Computed:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "The Azure Region where the Key Vault exists.",
+				Computed:            true,
+			},
+			"vault_uri": schema.StringAttribute{
+				MarkdownDescription: "The URI of the Key Vault, used for performing operations on secrets.",
+				Computed:            true,
+			},
+			"soft_delete_retention_days": schema.Int32Attribute{
+				MarkdownDescription: "The number of days that items are retained once soft-deleted.",
+				Computed:            true,
+			},
+			"purge_protection_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether purge protection is enabled, i.e. soft-deleted items can't be purged until the retention period elapses.",
+				Computed:            true,
+			},
+			"rbac_authorization_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether Azure RBAC is used to authorize data actions instead of access policies.",
+				Computed:            true,
+			},
+			"public_network_access_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Key Vault is accessible from public networks, which are still subject to `network_acls`.",
+				Computed:            true,
+			},
+			"network_acls": schema.SingleNestedAttribute{
+				MarkdownDescription: "The summary of the network rules of the Key Vault.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"default_action": schema.StringAttribute{
+						MarkdownDescription: "The action when no rules match, which is `Allow` or `Deny`.",
+						Computed:            true,
+					},
+					"bypass": schema.StringAttribute{
+						MarkdownDescription: "Which traffic can bypass the rules, which is `AzureServices` or `None`.",
+						Computed:            true,
+					},
+					"ip_rules": schema.SetAttribute{
+						MarkdownDescription: "The IP addresses or CIDR blocks allowed to access the Key Vault.",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+					"virtual_network_subnet_ids": schema.SetAttribute{
+						MarkdownDescription: "The IDs of the subnets allowed to access the Key Vault.",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *KeyVaultDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
+}
+
+func (d *KeyVaultDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model KeyVaultDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyVaultID.IsNull() {
+		if d.defaultKeyVaultID == "" {
+			resp.Diagnostics.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		model.KeyVaultID = types.StringValue(d.defaultKeyVaultID)
+	}
+	model.ID = model.KeyVaultID
+
+	kv, err := d.client.GetKeyVault(ctx, model.KeyVaultID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Get Key Vault", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(model.setKeyVault(ctx, kv)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,56 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccKeyVaultDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: keyVaultDataSourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurekv_key_vault.test", "id", keyVaultIDInConfig(rn)),
+					testCheckResourceAttrPairs("data.azurekv_key_vault.test", "data.azurerm_key_vault.test", []string{
+						"name",
+						"vault_uri",
+						"purge_protection_enabled",
+						"public_network_access_enabled",
+					}),
+					resource.TestCheckResourceAttrSet("data.azurekv_key_vault.test", "soft_delete_retention_days"),
+					resource.TestCheckResourceAttrSet("data.azurekv_key_vault.test", "rbac_authorization_enabled"),
+					resource.TestCheckResourceAttrSet("data.azurekv_key_vault.test", "network_acls.default_action"),
+				),
+			},
+		},
+	})
+}
+
+func keyVaultDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+data "azurerm_key_vault" "test" {
+  name                = element(split("/", local.key_vault_id), 8)
+  resource_group_name = element(split("/", local.key_vault_id), 4)
+}
+
+data "azurekv_key_vault" "test" {
+  key_vault_id = local.key_vault_id
+}
+`, providersConfig(resourceSuffix))
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = (*KeyVaultListResource)(nil)
+var _ list.ListResourceWithConfigure = (*KeyVaultListResource)(nil)
+var _ list.ListResourceWithRawV6Schemas = (*KeyVaultListResource)(nil)
+
+func NewKeyVaultListResource() list.ListResource {
+	return &KeyVaultListResource{}
+}
+
+// KeyVaultListResource defines the list resource implementation.
+// This provider doesn't manage key vaults, so the resource and identity schemas
+// of the results are defined by RawV6Schemas.
+type KeyVaultListResource struct {
+	client Client
+}
+
+type KeyVaultListResourceConfigModel struct {
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+}
+
+type KeyVaultListResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Location          types.String `tfsdk:"location"`
+	Tags              types.Map    `tfsdk:"tags"`
+}
+
+type KeyVaultListResourceIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func (r *KeyVaultListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key_vault"
+}
+
+func (r *KeyVaultListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Key Vaults in the subscription. The `id` of each result can be used as `key_vault_id` of other resources and data sources.\n\n" +
+			"~> This list resource requires the `Microsoft.KeyVault/vaults/read` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the resource group to list Key Vaults in. Defaults to all the resource groups in the subscription.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *KeyVaultListResource) RawV6Schemas(_ context.Context, _ list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Description: "The ID of the Key Vault.",
+					Computed:    true,
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Description: "The name of the Key Vault.",
+					Computed:    true,
+				},
+				{
+					Name:        "resource_group_name",
+					Type:        tftypes.String,
+					Description: "The name of the resource group in which the Key Vault exists.",
+					Computed:    true,
+				},
+				{
+					Name:        "location",
+					Type:        tftypes.String,
+					Description: "The Azure Region in which the Key Vault exists.",
+					Computed:    true,
+				},
+				{
+					Name:        "tags",
+					Type:        tftypes.Map{ElementType: tftypes.String},
+					Description: "A mapping of tags assigned to the Key Vault.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+	resp.ProtoV6IdentitySchema = &tfprotov6.ResourceIdentitySchema{
+		IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+			{
+				Name:              "id",
+				Type:              tftypes.String,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *KeyVaultListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *KeyVaultListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config KeyVaultListResourceConfigModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if r.client.GetSubscriptionID() == "" {
+		diags.AddError(
+			"Missing Configuration",
+			"Subscription ID is required to list key vaults",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	keyVaults, err := r.client.ListKeyVaults(ctx, config.ResourceGroupName.ValueString())
+	if err != nil {
+		diags.AddError("Failed to List Key Vaults", err.Error()+azureRequestDetails(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, keyVault := range keyVaults {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = *keyVault.Name
+
+			id, err := arm.ParseResourceID(*keyVault.ID)
+			if err != nil {
+				result.Diagnostics.AddError("Invalid Key Vault ID", err.Error())
+				push(result)
+				return
+			}
+
+			identity := KeyVaultListResourceIdentityModel{
+				ID: types.StringPointerValue(keyVault.ID),
+			}
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				tags, diags := types.MapValueFrom(ctx, types.StringType, keyVault.Tags)
+				result.Diagnostics.Append(diags...)
+
+				model := KeyVaultListResourceModel{
+					ID:                types.StringPointerValue(keyVault.ID),
+					Name:              types.StringPointerValue(keyVault.Name),
+					ResourceGroupName: types.StringValue(id.ResourceGroupName),
+					Location:          types.StringPointerValue(keyVault.Location),
+					Tags:              tags,
+				}
+				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
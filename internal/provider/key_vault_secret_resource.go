@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithConfigure = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithImportState = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithIdentity = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithConfigValidators = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithMoveState = (*KeyVaultSecretResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*KeyVaultSecretResource)(nil)
+
+func NewKeyVaultSecretResource() resource.Resource {
+	return &KeyVaultSecretResource{}
+}
+
+// KeyVaultSecretResource is an alias of SecretResource named after azurerm_key_vault_secret,
+// so that configurations can be migrated from azurerm by replacing the provider and the resource type prefix.
+type KeyVaultSecretResource struct {
+	SecretResource
+}
+
+func (r *KeyVaultSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key_vault_secret"
+}
+
+func (r *KeyVaultSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	r.SecretResource.Schema(ctx, req, resp)
+	resp.Schema.MarkdownDescription = "Manages a Key Vault secret. This resource is an alias of `azurekv_secret` named after [`azurerm_key_vault_secret`](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_secret), " +
+		"so that configurations using `azurerm_key_vault_secret` can be migrated by replacing `azurerm_key_vault_secret` with `azurekv_key_vault_secret`. " +
+		"The existing state can be moved with a [`moved` block](https://developer.hashicorp.com/terraform/language/moved) from `azurerm_key_vault_secret` in Terraform 1.8 and later.\n\n" +
+		"Unlike `azurerm_key_vault_secret`, this resource does not require the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission. See `azurekv_secret` for the details."
+}
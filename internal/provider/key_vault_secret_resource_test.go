@@ -0,0 +1,140 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccKeyVaultSecretResource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: keyVaultSecretResourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurekv_key_vault_secret.test", "name", "secret-name-"+rn),
+					resource.TestCheckResourceAttrPair("azurekv_key_vault_secret.test", "version", "data.azurerm_key_vault_secret.test", "version"),
+				),
+			},
+			{
+				ResourceName:            "azurekv_key_vault_secret.test",
+				ImportState:             true,
+				ImportStateIdFunc:       importStateIDFromAttribute("azurekv_key_vault_secret.test", "versionless_id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importStateVerifyIgnore,
+			},
+		},
+	})
+}
+
+func TestAccKeyVaultSecretResource_moveFromAzurermKeyVaultSecret(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: azurermKeyVaultSecretConfig(rn),
+			},
+			{
+				Config: movedFromAzurermKeyVaultSecretConfig(rn),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("azurekv_key_vault_secret.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurekv_key_vault_secret.test", "name", "secret-name-"+rn),
+					resource.TestCheckResourceAttr("azurekv_key_vault_secret.test", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr("azurekv_key_vault_secret.test", "tags.env", "test"),
+				),
+			},
+		},
+	})
+}
+
+func keyVaultSecretResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+}
+
+data "azurerm_key_vault_secret" "test" {
+  name         = azurekv_key_vault_secret.test.name
+  key_vault_id = local.key_vault_id
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func azurermKeyVaultSecretConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+  content_type     = "text/plain"
+
+  tags = {
+    env = "test"
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func movedFromAzurermKeyVaultSecretConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+moved {
+  from = azurerm_key_vault_secret.test
+  to   = azurekv_key_vault_secret.test
+}
+
+resource "azurekv_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+  content_type     = "text/plain"
+
+  tags = {
+    env = "test"
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	azlog "github.com/Azure/azure-sdk-for-go/sdk/azcore/log"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redactedLogValue = "REDACTED"
+
+// The environment variables configuring the logs of the Azure SDK, which are output with TF_LOG=DEBUG.
+// They can't be provider attributes because the logs are emitted before and during the provider configuration.
+const (
+	// LogEventsEnvVar is the comma-separated event types to log, such as "Request,ResponseError". All the events are logged if empty.
+	LogEventsEnvVar = "AZUREKV_LOG_EVENTS"
+	// LogHeadersEnvVar is the comma-separated headers logged in addition to those allowed by the Azure SDK.
+	LogHeadersEnvVar = "AZUREKV_LOG_HEADERS"
+)
+
+var logEvents = []azlog.Event{
+	azlog.EventRequest,
+	azlog.EventResponse,
+	azlog.EventResponseError,
+	azlog.EventRetryPolicy,
+	azlog.EventLRO,
+	azidentity.EventAuthentication,
+}
+
+// sensitiveHeaders are never logged even if they are specified in AZUREKV_LOG_HEADERS.
+var sensitiveHeaders = []string{
+	// The access key of the Event Grid topic to which the secret events are published
+	"aeg-sas-key",
+	"authorization",
+	"cookie",
+	"proxy-authorization",
+	"set-cookie",
+	"x-ms-authorization-auxiliary",
+}
+
+var (
+	// The header lines are formatted as "   Name: value" by the logging policy of azcore
+	sensitiveHeaderLineRegexp = regexp.MustCompile(`(?mi)^(\s*(?:` + strings.Join(sensitiveHeaders, "|") + `)\s*:\s*).*$`)
+	// Secret values appear in the bodies of Key Vault and the tokens in those of Microsoft Entra ID
+	sensitiveJSONFieldRegexp = regexp.MustCompile(`(?i)("(?:value|access_token|refresh_token|id_token|client_secret|client_assertion)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	sensitiveFormFieldRegexp = regexp.MustCompile(`(?i)\b((?:client_secret|client_assertion|assertion|refresh_token|password)=)[^&\s"]+`)
+	bearerTokenRegexp        = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// ParseLogEvents parses the value of AZUREKV_LOG_EVENTS. It returns nil if the value is empty, which means all the events.
+func ParseLogEvents(s string) ([]azlog.Event, error) {
+	var events []azlog.Event
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		i := slices.IndexFunc(logEvents, func(e azlog.Event) bool {
+			return strings.EqualFold(string(e), name)
+		})
+		if i < 0 {
+			names := make([]string, len(logEvents))
+			for i, e := range logEvents {
+				names[i] = string(e)
+			}
+			return nil, fmt.Errorf("unknown log event %q in %s, expected one of %s", name, LogEventsEnvVar, strings.Join(names, ", "))
+		}
+		events = append(events, logEvents[i])
+	}
+	return events, nil
+}
+
+// logHeaders parses the value of AZUREKV_LOG_HEADERS, excluding the sensitive headers.
+func logHeaders(s string) []string {
+	var headers []string
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(sensitiveHeaders, strings.ToLower(name)) {
+			continue
+		}
+		headers = append(headers, http.CanonicalHeaderKey(name))
+	}
+	return headers
+}
+
+// RedactLogMessage masks the credentials and secret values in the log message of the Azure SDK.
+// The logging policy of azcore already redacts headers not allowed explicitly and doesn't log bodies,
+// but error messages include response bodies and the allowed headers are configurable,
+// so the message is masked regardless of the SDK settings.
+func RedactLogMessage(msg string) string {
+	msg = sensitiveHeaderLineRegexp.ReplaceAllString(msg, "${1}"+redactedLogValue)
+	msg = sensitiveJSONFieldRegexp.ReplaceAllString(msg, `${1}"`+redactedLogValue+`"`)
+	msg = sensitiveFormFieldRegexp.ReplaceAllString(msg, "${1}"+redactedLogValue)
+	msg = bearerTokenRegexp.ReplaceAllString(msg, "${1}"+redactedLogValue)
+	return msg
+}
+
+// The keys of the fields attached to the logs emitted while a request to Azure is processed.
+const (
+	LogKeyVaultName  = "vault_name"
+	LogKeySecretName = "secret_name"
+	LogKeyOperation  = "operation"
+	LogKeyAttempt    = "attempt"
+	LogKeyDurationMS = "duration_ms"
+	LogKeyStatusCode = "status_code"
+)
+
+// vaultRequest identifies the key vault to which a request is sent.
+// It is attached to the context by requestLogPolicy of the secret client of the vault,
+// because the vault name can't be derived from the host if custom_vault_endpoint is set.
+type vaultRequest struct {
+	vaultName string
+	// basePath is the path of the vault URL, which is not empty if custom_vault_endpoint has a path.
+	basePath string
+}
+
+type vaultRequestKey struct{}
+
+// newVaultRequest returns the vaultRequest of the key vault at the URL.
+func newVaultRequest(vaultName, vaultURL string) (*vaultRequest, error) {
+	u, err := url.Parse(vaultURL)
+	if err != nil {
+		return nil, err
+	}
+	return &vaultRequest{vaultName: vaultName, basePath: strings.TrimSuffix(u.Path, "/")}, nil
+}
+
+// vaultRequestOf returns the key vault to which the request is sent, or false if the request isn't sent to Key Vault, such as requests to ARM.
+func vaultRequestOf(req *http.Request) (*vaultRequest, bool) {
+	v, ok := req.Context().Value(vaultRequestKey{}).(*vaultRequest)
+	return v, ok
+}
+
+// pathSegments returns the segments of the path relative to the vault URL,
+// such as ["secrets", "{name}", "{version}"] or ["deletedsecrets", "{name}"].
+func (v *vaultRequest) pathSegments(u *url.URL) []string {
+	return strings.Split(strings.Trim(strings.TrimPrefix(u.Path, v.basePath), "/"), "/")
+}
+
+// requestLogPolicy attaches the fields identifying the request to the context,
+// so that the logs of the request and its retries can be filtered by the vault, the secret, and the operation.
+type requestLogPolicy struct {
+	// vault is the key vault to which the client sends requests, or nil for the clients of the other APIs.
+	vault *vaultRequest
+}
+
+var _ policy.Policy = (*requestLogPolicy)(nil)
+
+// requestAttempts is the number of the tries of a call, which is shared by requestLogPolicy and tryLogPolicy.
+type requestAttempts struct {
+	count int
+}
+
+func (p *requestLogPolicy) Do(req *policy.Request) (*http.Response, error) {
+	raw := req.Raw()
+	ctx := raw.Context()
+	if p.vault != nil {
+		ctx = context.WithValue(ctx, vaultRequestKey{}, p.vault)
+		ctx = tflog.SetField(ctx, LogKeyVaultName, p.vault.vaultName)
+		if segments := p.vault.pathSegments(raw.URL); len(segments) >= 2 {
+			ctx = tflog.SetField(ctx, LogKeySecretName, segments[1])
+		}
+	}
+	ctx = tflog.SetField(ctx, LogKeyOperation, operationName(raw.WithContext(ctx)))
+
+	req.SetOperationValue(&requestAttempts{})
+	return req.Clone(ctx).Next()
+}
+
+// tryLogPolicy logs each try of a request with its attempt number, duration, and result.
+type tryLogPolicy struct{}
+
+var _ policy.Policy = (*tryLogPolicy)(nil)
+
+func (p *tryLogPolicy) Do(req *policy.Request) (*http.Response, error) {
+	var attempts *requestAttempts
+	if !req.OperationValue(&attempts) {
+		attempts = &requestAttempts{}
+	}
+	attempts.count++
+
+	start := time.Now()
+	resp, err := req.Next()
+
+	fields := map[string]any{
+		LogKeyAttempt:    attempts.count,
+		LogKeyDurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields[LogKeyStatusCode] = resp.StatusCode
+	}
+	tflog.Debug(req.Raw().Context(), "Sent a request to Azure", fields)
+
+	return resp, err
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+const (
+	mockSubscriptionID = "00000000-0000-0000-0000-000000000000"
+	mockVersion        = "00000000000000000000000000000000"
+	mockSecretValue    = "mock"
+	mockPrincipalID    = "00000000-0000-0000-0000-000000000000"
+	mockLocation       = "eastus"
+)
+
+// errMockMode is returned by the operations that change Azure, so that applies are blocked in mock mode.
+var errMockMode = errors.New("mock_mode is enabled, so no changes can be applied to Azure; disable mock_mode to apply")
+
+// mockClient returns deterministic fakes without calling Azure, so that terraform plan can run without any credentials,
+// e.g. on pull requests from forks that have no cloud access.
+type mockClient struct {
+	subscriptionID string
+}
+
+var _ Client = (*mockClient)(nil)
+
+func newMockClient(subscriptionID string) *mockClient {
+	if subscriptionID == "" {
+		subscriptionID = mockSubscriptionID
+	}
+	return &mockClient{subscriptionID: subscriptionID}
+}
+
+func (c *mockClient) GetSubscriptionID() string {
+	return c.subscriptionID
+}
+
+func (c *mockClient) VaultURL(vaultName string) string {
+	return defaultVaultURL(vaultName)
+}
+
+func (c *mockClient) GetSecretProperties(_ context.Context, keyVaultID, name string, version string, _ *azsecrets.ListSecretPropertiesVersionsOptions) (*azsecrets.SecretProperties, error) {
+	if version == "" {
+		version = mockVersion
+	}
+	id, err := mockSecretID(keyVaultID, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azsecrets.SecretProperties{
+		ID: id,
+		Attributes: &azsecrets.SecretAttributes{
+			Created: to.Ptr(time.Unix(0, 0).UTC()),
+			Updated: to.Ptr(time.Unix(0, 0).UTC()),
+			Enabled: to.Ptr(true),
+		},
+		Tags: map[string]*string{},
+	}, nil
+}
+
+func (c *mockClient) ListSecretPropertiesVersions(ctx context.Context, keyVaultID, name string) ([]*azsecrets.SecretProperties, error) {
+	props, err := c.GetSecretProperties(ctx, keyVaultID, name, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return []*azsecrets.SecretProperties{props}, nil
+}
+
+// ListSecretProperties returns no secrets, since the secrets in the key vault are unknown in mock mode.
+func (c *mockClient) ListSecretProperties(_ context.Context, keyVaultID string, _ *ListSecretPropertiesOptions) iter.Seq2[*azsecrets.SecretProperties, error] {
+	return func(yield func(*azsecrets.SecretProperties, error) bool) {
+		if _, err := extractVaultName(keyVaultID); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (c *mockClient) GetSecret(ctx context.Context, keyVaultID, name string, version string, _ *azsecrets.GetSecretOptions) (azsecrets.GetSecretResponse, error) {
+	props, err := c.GetSecretProperties(ctx, keyVaultID, name, version, nil)
+	if err != nil {
+		return azsecrets.GetSecretResponse{}, err
+	}
+
+	return azsecrets.GetSecretResponse{
+		Secret: azsecrets.Secret{
+			ID:         props.ID,
+			Attributes: props.Attributes,
+			Tags:       props.Tags,
+			Value:      to.Ptr(mockSecretValue),
+		},
+	}, nil
+}
+
+func (c *mockClient) SetSecret(context.Context, string, string, azsecrets.SetSecretParameters, *azsecrets.SetSecretOptions) (azsecrets.SetSecretResponse, error) {
+	return azsecrets.SetSecretResponse{}, errMockMode
+}
+
+func (c *mockClient) UpdateSecretProperties(context.Context, string, string, string, azsecrets.UpdateSecretPropertiesParameters, *azsecrets.UpdateSecretPropertiesOptions) (azsecrets.UpdateSecretPropertiesResponse, error) {
+	return azsecrets.UpdateSecretPropertiesResponse{}, errMockMode
+}
+
+func (c *mockClient) DeleteSecret(context.Context, string, string, *azsecrets.DeleteSecretOptions) (azsecrets.DeleteSecretResponse, error) {
+	return azsecrets.DeleteSecretResponse{}, errMockMode
+}
+
+// GetDeletedSecret returns 404 Not Found, since all the secrets exist in mock mode.
+func (c *mockClient) GetDeletedSecret(context.Context, string, string, *azsecrets.GetDeletedSecretOptions) (azsecrets.GetDeletedSecretResponse, error) {
+	return azsecrets.GetDeletedSecretResponse{}, &azcore.ResponseError{StatusCode: http.StatusNotFound, ErrorCode: "SecretNotFound"}
+}
+
+func (c *mockClient) PurgeDeletedSecret(context.Context, string, string, *azsecrets.PurgeDeletedSecretOptions) (azsecrets.PurgeDeletedSecretResponse, error) {
+	return azsecrets.PurgeDeletedSecretResponse{}, errMockMode
+}
+
+func (c *mockClient) RecoverDeletedSecret(context.Context, string, string, *azsecrets.RecoverDeletedSecretOptions) (azsecrets.RecoverDeletedSecretResponse, error) {
+	return azsecrets.RecoverDeletedSecretResponse{}, errMockMode
+}
+
+func (c *mockClient) BackupSecret(context.Context, string, string, *azsecrets.BackupSecretOptions) (azsecrets.BackupSecretResponse, error) {
+	return azsecrets.BackupSecretResponse{}, errMockMode
+}
+
+func (c *mockClient) GetKeyVaultID(_ context.Context, name string, _ *GetKeyVaultIDOptions) (string, error) {
+	return "/subscriptions/" + c.subscriptionID + "/resourceGroups/mock/providers/Microsoft.KeyVault/vaults/" + name, nil
+}
+
+func (c *mockClient) ListKeyVaults(context.Context, string) ([]*armresources.GenericResourceExpanded, error) {
+	return nil, nil
+}
+
+// CheckVaultHealth reports that all the checks passed, so that smoke tests don't fail plans in mock mode.
+func (c *mockClient) CheckVaultHealth(context.Context, string) VaultHealth {
+	return VaultHealth{}
+}
+
+// ListDataPlaneRoleAssignments returns no role assignments, since the principal is unknown in mock mode.
+func (c *mockClient) ListDataPlaneRoleAssignments(_ context.Context, keyVaultID string) (*DataPlaneRoleAssignments, error) {
+	if _, err := extractVaultName(keyVaultID); err != nil {
+		return nil, err
+	}
+	return &DataPlaneRoleAssignments{PrincipalID: mockPrincipalID}, nil
+}
+
+// GetKeyVault returns a key vault with the default properties of a new key vault.
+func (c *mockClient) GetKeyVault(_ context.Context, keyVaultID string) (*KeyVault, error) {
+	vaultName, err := extractVaultName(keyVaultID)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyVault{
+		ID:                      keyVaultID,
+		Name:                    vaultName,
+		Location:                mockLocation,
+		VaultURI:                defaultVaultURL(vaultName) + "/",
+		SoftDeleteRetentionDays: defaultSoftDeleteRetentionDays,
+		PublicNetworkAccess:     defaultPublicNetworkAccess,
+		NetworkACLs: KeyVaultNetworkACLs{
+			DefaultAction:           defaultNetworkACLsAction,
+			Bypass:                  defaultNetworkACLsBypass,
+			IPRules:                 []string{},
+			VirtualNetworkSubnetIDs: []string{},
+		},
+	}, nil
+}
+
+// NotifyRotation does nothing, since no secrets are rotated in mock mode.
+func (c *mockClient) NotifyRotation(context.Context, RotationEvent) error {
+	return nil
+}
+
+// PublishEvent does nothing, since no secrets are written or deleted in mock mode.
+func (c *mockClient) PublishEvent(context.Context, string, string, string, string) error {
+	return nil
+}
+
+// ReportMetrics does nothing, since Azure is never called in mock mode.
+func (c *mockClient) ReportMetrics() error {
+	return nil
+}
+
+func mockSecretID(keyVaultID, name, version string) (*azsecrets.ID, error) {
+	vaultName, err := extractVaultName(keyVaultID)
+	if err != nil {
+		return nil, err
+	}
+	return to.Ptr(azsecrets.ID(defaultVaultURL(vaultName) + "/secrets/" + name + "/" + version)), nil
+}
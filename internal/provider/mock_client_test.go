@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMockClient(t *testing.T) {
+	t.Parallel()
+
+	c := newMockClient("")
+	if got := c.GetSubscriptionID(); got != mockSubscriptionID {
+		t.Errorf("GetSubscriptionID() = %q, want %q", got, mockSubscriptionID)
+	}
+
+	keyVaultID, err := c.GetKeyVaultID(t.Context(), vaultName, nil)
+	if err != nil {
+		t.Fatalf("GetKeyVaultID() error = %v", err)
+	}
+
+	props, err := c.GetSecretProperties(t.Context(), keyVaultID, "secret-name", "", nil)
+	if err != nil {
+		t.Fatalf("GetSecretProperties() error = %v", err)
+	}
+	if want := azsecrets.ID(testVaultURL + "/secrets/secret-name/" + mockVersion); *props.ID != want {
+		t.Errorf("GetSecretProperties() returned ID %q, want %q", *props.ID, want)
+	}
+
+	var count int
+	for _, err := range c.ListSecretProperties(t.Context(), keyVaultID, nil) {
+		if err != nil {
+			t.Fatalf("ListSecretProperties() error = %v", err)
+		}
+		count++
+	}
+	if count != 0 {
+		t.Errorf("ListSecretProperties() returned %d secrets, want 0", count)
+	}
+
+	getResp, err := c.GetSecret(t.Context(), keyVaultID, "secret-name", "version", nil)
+	if err != nil {
+		t.Fatalf("GetSecret() error = %v", err)
+	}
+	if got := getResp.ID.Version(); got != "version" {
+		t.Errorf("GetSecret() returned version %q, want %q", got, "version")
+	}
+
+	if _, err := c.SetSecret(t.Context(), keyVaultID, "secret-name", azsecrets.SetSecretParameters{}, nil); !errors.Is(err, errMockMode) {
+		t.Errorf("SetSecret() error = %v, want %v", err, errMockMode)
+	}
+	if _, err := c.DeleteSecret(t.Context(), keyVaultID, "secret-name", nil); !errors.Is(err, errMockMode) {
+		t.Errorf("DeleteSecret() error = %v, want %v", err, errMockMode)
+	}
+
+	if _, err := c.GetDeletedSecret(t.Context(), keyVaultID, "secret-name", nil); !isNotFoundError(err) {
+		t.Errorf("GetDeletedSecret() error = %v, want 404 Not Found", err)
+	}
+
+	if _, err := c.GetSecretProperties(t.Context(), "invalid", "secret-name", "", nil); err == nil {
+		t.Error("GetSecretProperties() with an invalid key vault ID succeeded unexpectedly")
+	}
+}
+
+func TestSecretResourceRefreshInMockMode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := nullObjectValue(providerType, map[string]tftypes.Value{
+		"mock_mode": tftypes.NewValue(tftypes.Bool, true),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: newTestDynamicValue(t, providerType, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, configureResp.Diagnostics)
+
+	// Build the existing state from the state of the version 0 with the tags and the checksum tracked
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "azurekv_secret",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(secretStateV0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, upgradeResp.Diagnostics)
+
+	resourceType := schemaResp.ResourceSchemas["azurekv_secret"].ValueType().(tftypes.Object)
+	upgraded, err := upgradeResp.UpgradedState.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := upgraded.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	attrs["track_value_checksum"] = tftypes.NewValue(tftypes.Bool, true)
+	attrs["value_checksum"] = tftypes.NewValue(tftypes.String, "checksum")
+	attrs["detect_external_changes"] = tftypes.NewValue(tftypes.Bool, true)
+	prior := tftypes.NewValue(resourceType, attrs)
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "azurekv_secret",
+		CurrentState: newTestDynamicValue(t, resourceType, prior),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, readResp.Diagnostics)
+
+	refreshed, err := readResp.NewState.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := nullObjectValue(resourceType, map[string]tftypes.Value{
+		"name":                    attrs["name"],
+		"key_vault_id":            attrs["key_vault_id"],
+		"value_wo":                tftypes.NewValue(tftypes.String, "secret-value"),
+		"value_wo_version":        tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+		"tags":                    attrs["tags"],
+		"track_value_checksum":    tftypes.NewValue(tftypes.Bool, true),
+		"detect_external_changes": tftypes.NewValue(tftypes.Bool, true),
+	})
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "azurekv_secret",
+		PriorState:       readResp.NewState,
+		ProposedNewState: newTestDynamicValue(t, resourceType, proposedNewState(resourceType, schemaResp.ResourceSchemas["azurekv_secret"], refreshed, config)),
+		Config:           newTestDynamicValue(t, resourceType, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, planResp.Diagnostics)
+
+	planned, err := planResp.PlannedState.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := prior.Diff(planned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range diffs {
+		t.Errorf("unexpected diff at %s: %v -> %v", d.Path, d.Value1, d.Value2)
+	}
+}
+
+func TestResourceCreateInMockMode(t *testing.T) {
+	t.Parallel()
+
+	keyVaultID := tftypes.NewValue(tftypes.String, testKeyVaultID)
+	tests := []struct {
+		typeName string
+		attrs    map[string]tftypes.Value
+	}{
+		{
+			typeName: "azurekv_secret",
+			attrs: map[string]tftypes.Value{
+				"name":         tftypes.NewValue(tftypes.String, "secret-name"),
+				"key_vault_id": keyVaultID,
+				"value":        tftypes.NewValue(tftypes.String, "secret-value"),
+			},
+		},
+		{
+			typeName: "azurekv_replicated_secret",
+			attrs: map[string]tftypes.Value{
+				"name":          tftypes.NewValue(tftypes.String, "secret-name"),
+				"key_vault_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{keyVaultID}),
+				"value_wo":      tftypes.NewValue(tftypes.String, "secret-value"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			server, err := providerserver.NewProtocol6WithError(New("test")())()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+			providerConfig := nullObjectValue(providerType, map[string]tftypes.Value{
+				"mock_mode": tftypes.NewValue(tftypes.Bool, true),
+			})
+			configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+				Config: newTestDynamicValue(t, providerType, providerConfig),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertNoDiagnostics(t, configureResp.Diagnostics)
+
+			resourceSchema := schemaResp.ResourceSchemas[tt.typeName]
+			resourceType := resourceSchema.ValueType().(tftypes.Object)
+			prior := tftypes.NewValue(resourceType, nil)
+			config := nullObjectValue(resourceType, tt.attrs)
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         tt.typeName,
+				PriorState:       newTestDynamicValue(t, resourceType, prior),
+				ProposedNewState: newTestDynamicValue(t, resourceType, proposedNewState(resourceType, resourceSchema, nullObjectValue(resourceType, nil), config)),
+				Config:           newTestDynamicValue(t, resourceType, config),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertNoDiagnostics(t, planResp.Diagnostics)
+
+			applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     tt.typeName,
+				PriorState:   newTestDynamicValue(t, resourceType, prior),
+				PlannedState: planResp.PlannedState,
+				Config:       newTestDynamicValue(t, resourceType, config),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found bool
+			for _, d := range applyResp.Diagnostics {
+				if d.Severity != tfprotov6.DiagnosticSeverityError {
+					continue
+				}
+				if d.Summary != "Mock Mode Enabled" || !strings.Contains(d.Detail, errMockMode.Error()) {
+					t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+				}
+				found = true
+			}
+			if !found {
+				t.Errorf("ApplyResourceChange() returned no error, want %q", errMockMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	// privateKeyValueChecksum is the private state key storing the checksum of the value written by this provider.
+	privateKeyValueChecksum = "value_checksum"
+	// privateKeyVersion is the private state key storing the version written by this provider.
+	privateKeyVersion = "version"
+)
+
+// privateState is implemented by the private state data of requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getPrivateString returns the string stored in the private state, or an empty string if the key doesn't exist.
+func getPrivateString(ctx context.Context, private privateState, key string) (string, diag.Diagnostics) {
+	b, diags := private.GetKey(ctx, key)
+	if diags.HasError() || b == nil {
+		return "", diags
+	}
+
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		diags.AddError("Invalid Private State", "An unexpected error occurred while reading "+key+" from the private state: "+err.Error())
+	}
+	return v, diags
+}
+
+func setPrivateString(ctx context.Context, private privateState, key, value string) diag.Diagnostics {
+	b, err := json.Marshal(value)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid Private State", "An unexpected error occurred while writing "+key+" to the private state: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, key, b)
+}
+
+// writtenSecret is the secret last written by this provider, which is used to detect changes outside of Terraform.
+type writtenSecret struct {
+	version       string
+	valueChecksum string
+}
+
+func newWrittenSecret(model SecretResourceModel, value string) writtenSecret {
+	written := writtenSecret{
+		version: model.Version.ValueString(),
+	}
+	if model.TrackValueChecksum.ValueBool() {
+		written.valueChecksum = valueChecksum(value)
+	}
+	return written
+}
+
+func getWrittenSecret(ctx context.Context, private privateState) (writtenSecret, diag.Diagnostics) {
+	var written writtenSecret
+	var diags, d diag.Diagnostics
+
+	written.version, d = getPrivateString(ctx, private, privateKeyVersion)
+	diags.Append(d...)
+	written.valueChecksum, d = getPrivateString(ctx, private, privateKeyValueChecksum)
+	diags.Append(d...)
+
+	return written, diags
+}
+
+func setWrittenSecret(ctx context.Context, private privateState, written writtenSecret) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(setPrivateString(ctx, private, privateKeyVersion, written.version)...)
+	diags.Append(setPrivateString(ctx, private, privateKeyValueChecksum, written.valueChecksum)...)
+
+	return diags
+}
@@ -2,17 +2,42 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure AzurekvProvider satisfies various provider interfaces.
 var _ provider.Provider = (*AzurekvProvider)(nil)
+var _ provider.ProviderWithActions = (*AzurekvProvider)(nil)
+var _ provider.ProviderWithListResources = (*AzurekvProvider)(nil)
+var _ provider.ProviderWithEphemeralResources = (*AzurekvProvider)(nil)
 
 // AzurekvProvider defines the provider implementation.
 type AzurekvProvider struct {
@@ -20,11 +45,77 @@ type AzurekvProvider struct {
 	// provider is built and run locally, and "test" when running acceptance
 	// testing.
 	version string
+	// clients are the clients configured by the provider, whose metrics are reported by ReportMetrics.
+	clients      []Client
+	clientsMutex sync.Mutex
 }
 
 // AzurekvProviderModel describes the provider data model.
 type AzurekvProviderModel struct {
-	SubscriptionID types.String `tfsdk:"subscription_id"`
+	SubscriptionID            types.String         `tfsdk:"subscription_id"`
+	DiscoverSubscriptionID    types.Bool           `tfsdk:"discover_subscription_id"`
+	PurgeSoftDeleteOnDestroy  types.Bool           `tfsdk:"purge_soft_delete_on_destroy"`
+	ExpirationWarningDays     types.Int32          `tfsdk:"expiration_warning_days"`
+	DefaultTags               types.Map            `tfsdk:"default_tags"`
+	AutomaticTags             types.Map            `tfsdk:"automatic_tags"`
+	VaultAliases              types.Map            `tfsdk:"vault_aliases"`
+	KeyVaultID                types.String         `tfsdk:"key_vault_id"`
+	RequiredTags              types.List           `tfsdk:"required_tags"`
+	NamePattern               types.String         `tfsdk:"name_pattern"`
+	RBACPropagationTimeout    timetypes.GoDuration `tfsdk:"rbac_propagation_timeout"`
+	PurgeWaitTimeout          timetypes.GoDuration `tfsdk:"purge_wait_timeout"`
+	DNSPropagationTimeout     timetypes.GoDuration `tfsdk:"dns_propagation_timeout"`
+	VersionPropagationTimeout timetypes.GoDuration `tfsdk:"version_propagation_timeout"`
+	RequestTimeout            timetypes.GoDuration `tfsdk:"request_timeout"`
+	MaxRequestsPerSecond      types.Int32          `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests     types.Int32          `tfsdk:"max_concurrent_requests"`
+	ProxyURL                  types.String         `tfsdk:"proxy_url"`
+	CABundleFile              types.String         `tfsdk:"ca_bundle_file"`
+	CABundlePEM               types.String         `tfsdk:"ca_bundle_pem"`
+	MinTLSVersion             types.String         `tfsdk:"min_tls_version"`
+	PartnerID                 types.String         `tfsdk:"partner_id"`
+	UserAgentSuffix           types.String         `tfsdk:"user_agent_suffix"`
+	OTLPTracesEndpoint        types.String         `tfsdk:"otlp_traces_endpoint"`
+	MetricsFile               types.String         `tfsdk:"metrics_file"`
+	AuditLogFile              types.String         `tfsdk:"audit_log_file"`
+	RotationWebhookURL        types.String         `tfsdk:"rotation_webhook_url"`
+	EventGridTopicEndpoint    types.String         `tfsdk:"event_grid_topic_endpoint"`
+	EventGridTopicKey         types.String         `tfsdk:"event_grid_topic_key"`
+	CustomVaultEndpoint       types.String         `tfsdk:"custom_vault_endpoint"`
+	InsecureSkipTLSVerify     types.Bool           `tfsdk:"insecure_skip_tls_verify"`
+	MaxIdleConnections        types.Int32          `tfsdk:"max_idle_connections"`
+	IdleConnectionTimeout     timetypes.GoDuration `tfsdk:"idle_connection_timeout"`
+	DisableKeepAlives         types.Bool           `tfsdk:"disable_keep_alives"`
+	AccessToken               types.String         `tfsdk:"access_token"`
+	MockMode                  types.Bool           `tfsdk:"mock_mode"`
+	RecoverSoftDeletedSecrets types.Bool           `tfsdk:"recover_soft_deleted_secrets"`
+}
+
+// ProviderData is passed to resources, data sources, actions, and list resources on Configure.
+type ProviderData struct {
+	Client Client
+	// PurgeSoftDeleteOnDestroy is the default of the purge_soft_delete_on_destroy attribute of resources.
+	PurgeSoftDeleteOnDestroy bool
+	// ExpirationWarningDays is the number of days before expiration within which plans warn. Zero disables the warning.
+	ExpirationWarningDays int32
+	// DefaultTags are merged into the tags of resources, including the rendered automatic tags.
+	DefaultTags map[string]string
+	// VaultAliases maps the logical names referenced by vault_alias to key vault IDs.
+	VaultAliases map[string]string
+	// DefaultKeyVaultID is the key vault ID used by resources and data sources that specify no key vault.
+	DefaultKeyVaultID string
+	// RequiredTags are the tag keys that resources must have, including the default tags.
+	RequiredTags []string
+	// NamePattern is the pattern that the names of managed secrets must match, or nil if any name is allowed.
+	NamePattern *regexp.Regexp
+	// RecoverSoftDeletedSecrets is whether to recover a deleted secret with the same name when creating a secret.
+	RecoverSoftDeletedSecrets bool
+	// RBACPropagationTimeout is how long creating a secret is retried while it fails with ForbiddenByRbac. Zero disables the retries.
+	RBACPropagationTimeout time.Duration
+	// PurgeWaitTimeout is how long creating a secret is retried while a secret with the same name is being deleted or purged. Zero disables the retries.
+	PurgeWaitTimeout time.Duration
+	// MockMode is whether Client is the mock client, in which case resources keep their prior state on refresh.
+	MockMode bool
 }
 
 func (p *AzurekvProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -36,15 +127,273 @@ func (p *AzurekvProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "The Azure Key Vault provider allows you to manage Key Vault secrets without requiring the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission, by leveraging [write-only arguments](https://developer.hashicorp.com/terraform/language/resources/ephemeral/write-only).",
 		Attributes: map[string]schema.Attribute{
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Specifies a string appended to the user agent of all the requests to Azure, such as the name of a pipeline, " +
+					"so that the requests can be identified in the diagnostics logs of Key Vault.",
+				Optional: true,
+			},
 			"subscription_id": schema.StringAttribute{
-				MarkdownDescription: "The subscription ID which should be used. This can also be sourced from the `ARM_SUBSCRIPTION_ID` environment variable and is only required for import using ID.",
+				MarkdownDescription: "The subscription ID which should be used. This can also be sourced from the `ARM_SUBSCRIPTION_ID` environment variable. It is required to resolve `vault_name` or `vault_uri` of `azurekv_secret` and the `azurekv_secret` data source to the Key Vault ID, and for the `azurekv_key_vault` list resource. On import using ID, the Key Vault is looked up in this subscription first, and then in the other subscriptions accessible with the credential.",
+				Optional:            true,
+			},
+			"discover_subscription_id": schema.BoolAttribute{
+				MarkdownDescription: "Whether to discover the subscription ID if neither `subscription_id` nor `ARM_SUBSCRIPTION_ID` is set, " +
+					"from the default subscription of the Azure CLI, or the only enabled subscription accessible with the credential, " +
+					"so that import works without looking up the subscription ID. " +
+					"Configuring the provider fails if multiple subscriptions are accessible. Defaults to `false`.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the maximum number of concurrent requests sent to Key Vault by this provider, independent of the `-parallelism` option of Terraform, " +
+					"so that the per-vault service limits are respected even when the rest of the plan runs wide. No limit is applied by default.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"max_requests_per_second": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the maximum number of requests per second sent to Key Vault by this provider, " +
+					"so that refreshing hundreds of secrets in parallel doesn't exceed the [service limits](https://learn.microsoft.com/en-us/azure/key-vault/general/service-limits). " +
+					"Regardless of this setting, all the requests are held for the duration of the `Retry-After` header once Key Vault throttles a request. No limit is applied by default.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"audit_log_file": schema.StringAttribute{
+				MarkdownDescription: "Specifies the path of a file to which a JSON line is appended for each write operation performed by the provider, " +
+					"namely setting, updating, deleting, purging, and recovering secrets, as evidence for change control. " +
+					"Each line has the time, the operation, the Key Vault ID, the secret name, the new version, and the identity of the caller, but never the secret value. " +
+					"No audit log is written by default.",
+				Optional: true,
+			},
+			"event_grid_topic_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Specifies the endpoint of an Event Grid custom topic, such as `https://example.westus2-1.eventgrid.azure.net/api/events`, " +
+					"to which an event in the Event Grid schema is published each time an `azurekv_secret` resource creates a new version of a secret or deletes a secret, " +
+					"so that event-driven pipelines can reload the secrets. The event type is `Azurekv.SecretNewVersionCreated` or `Azurekv.SecretDeleted`, " +
+					"and the data has the Key Vault ID, the secret name, and the new version, but never the secret value. " +
+					"The event is published with `event_grid_topic_key` if specified, or the credential of the provider, which requires the `EventGrid Data Sender` role. " +
+					"A failed publish is reported as a warning. No events are published by default.",
+				Optional: true,
+			},
+			"event_grid_topic_key": schema.StringAttribute{
+				MarkdownDescription: "Specifies the access key of the Event Grid topic of `event_grid_topic_endpoint`. Requires `event_grid_topic_endpoint`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("event_grid_topic_endpoint")),
+				},
+			},
+			"rotation_webhook_url": schema.StringAttribute{
+				MarkdownDescription: "Specifies the URL to which a JSON object is posted each time a secret resource creates a new version of an existing secret, " +
+					"so that dependent services and chat-ops can react to rotations without polling Key Vault. " +
+					"The object has the time, the Key Vault ID, the secret name, the new version, the previous version, and the expiration date, but never the secret value. " +
+					"Creating a secret is not a rotation, so it sends no notification. A failed notification is reported as a warning. No notification is sent by default.",
+				Optional: true,
+			},
+			"metrics_file": schema.StringAttribute{
+				MarkdownDescription: "Specifies the path of a file to which the summary of the API calls, such as the numbers of calls, retries, and throttled requests and the latencies per operation, is appended as a JSON line when the provider exits. " +
+					"The summary is also logged at the `INFO` level regardless of this setting. Terraform runs the provider for each of plan and apply, so each line has the time, the process ID (`pid`), and the number of the provider instance in the process (`instance`) besides the `operations`.",
+				Optional: true,
+			},
+			"min_tls_version": schema.StringAttribute{
+				MarkdownDescription: "Specifies the minimum TLS version for the requests to Azure. Possible values are `1.2` and `1.3`. Defaults to the minimum version of Go, which is `1.2`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tlsVersion12, tlsVersion13),
+				},
+			},
+			"otlp_traces_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Specifies the [OTLP/HTTP](https://opentelemetry.io/docs/specs/otlp/#otlphttp) endpoint to which the traces of the requests to Azure are exported, such as `http://localhost:4318/v1/traces`. " +
+					"Each Key Vault operation is recorded as a span with the vault name, the secret name, and the status. " +
+					"If not specified, the `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` environment variable or the `OTEL_EXPORTER_OTLP_ENDPOINT` environment variable suffixed with `/v1/traces` is used, " +
+					"and no traces are exported if neither is set. The other `OTEL_EXPORTER_OTLP_*` environment variables, such as `OTEL_EXPORTER_OTLP_HEADERS` and `OTEL_EXPORTER_OTLP_TIMEOUT`, and the `OTEL_SERVICE_NAME` and `OTEL_RESOURCE_ATTRIBUTES` environment variables are also respected.",
+				Optional: true,
+			},
+			"mock_mode": schema.BoolAttribute{
+				MarkdownDescription: "Whether to satisfy plans without calling Azure, so that `terraform plan` can run without any credentials, e.g. on pull requests from forks that have no cloud access. " +
+					"Data sources return deterministic fake values, resources keep their prior state on refresh, and applies are blocked by errors. " +
+					"This can also be sourced from the `AZUREKV_MOCK_MODE` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"partner_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies a GUID/UUID registered with Microsoft to facilitate partner resource usage attribution, as with the `azurerm` provider. " +
+					"`pid-` is added to the user agent of the requests unless it is already prefixed. This can also be sourced from the `ARM_PARTNER_ID` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(partnerIDRegex, "must be a GUID/UUID optionally prefixed with pid-"),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Specifies the URL of the proxy for all the requests to Azure, such as `http://proxy.example.com:3128`. " +
+					"Hosts in the `NO_PROXY` environment variable are still accessed directly. If not specified, the `HTTPS_PROXY` and `NO_PROXY` environment variables are used.",
+				Optional: true,
+			},
+			"purge_soft_delete_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to purge secrets after deleting them on destroy, so that secrets with the same names can be created immediately. " +
+					"This can be overridden by the `purge_soft_delete_on_destroy` attribute of each resource. Defaults to `false`.",
+				Optional: true,
+			},
+			"dns_propagation_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies how long to retry requests to Key Vault while the vault name can't be resolved or connected, such as `5m`. " +
+					"The DNS records of a newly created Key Vault, especially with a private endpoint, take time to propagate. No retry is made by default.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"version_propagation_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies how long to wait after setting a secret until the new version is listed by Key Vault, such as `30s`. " +
+					"Key Vault is eventually consistent, so data sources and replicas reading the secret immediately afterwards may observe the previous version without this. " +
+					"Only a warning is logged if the new version is not listed in time. No wait is made by default.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies the deadline of each request to Azure including its retries, such as `1m`, " +
+					"so that requests to an unreachable host, such as a private endpoint not routed from the network running Terraform, fail fast instead of stalling the run. " +
+					"No deadline is set by default.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"expiration_warning_days": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the number of days before `expiration_date` of a managed secret within which a warning is shown on plan, " +
+					"so that upcoming expirations are noticed. No warning is shown by default.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Specifies a static access token sent to Key Vault instead of authenticating with the [DefaultAzureCredential](https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/sdk/azidentity#DefaultAzureCredential), " +
+					"which is intended for a local emulator accepting any token along with `custom_vault_endpoint`. The token is never refreshed.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"custom_vault_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Specifies the URL of Key Vault, in which `{vault_name}` is replaced with the vault name, such as `https://{vault_name}.localhost:8443`, " +
+					"so that a local emulator such as [Lowkey Vault](https://github.com/nagyesta/lowkey-vault) can be targeted. " +
+					"Defaults to `https://{vault_name}.vault.azure.net`. Note that an emulator has no ARM API, so vaults must be specified with `key_vault_id` instead of `vault_name` or `vault_uri`. " +
+					"For the same reason, importing `azurekv_secret` by ID is unsupported; import it with the identity including `key_vault_id` instead.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(customVaultEndpointRegex, "must be an http or https URL"),
+				},
+			},
+			"insecure_skip_tls_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the verification of the server certificates, e.g. for the self-signed certificate of a local emulator. " +
+					"This must not be enabled against Azure. Defaults to `false`.",
+				Optional: true,
+			},
+			"max_idle_connections": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the maximum number of idle connections kept per host for reuse. Defaults to the default of the Go HTTP client, which is `2`.",
 				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"idle_connection_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies how long an idle connection is kept for reuse, such as `30s`. " +
+					"Set it shorter than the idle timeout of the middleboxes, such as firewalls and NAT gateways, that silently drop long-lived connections during big applies. " +
+					"Defaults to `90s`.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"disable_keep_alives": schema.BoolAttribute{
+				MarkdownDescription: "Whether to use a new connection for each request instead of reusing idle connections, " +
+					"as a last resort for the middleboxes that silently drop connections. This adds the latency of a TLS handshake to every request. Defaults to `false`.",
+				Optional: true,
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Specifies the path to the PEM-encoded CA certificates trusted in addition to the system ones, " +
+					"such as those of a TLS-inspecting proxy or a private PKI. Conflicts with `ca_bundle_pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_bundle_pem")),
+				},
+			},
+			"ca_bundle_pem": schema.StringAttribute{
+				MarkdownDescription: "Specifies the PEM-encoded CA certificates trusted in addition to the system ones, " +
+					"such as those of a TLS-inspecting proxy or a private PKI. Conflicts with `ca_bundle_file`.",
+				Optional: true,
+			},
+			"automatic_tags": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags stamped on all the secrets written by this provider, such as `{ managed-by = \"terraform\", tf-workspace = \"{{ .Workspace }}\" }`, " +
+					"so that Terraform-managed secrets can be distinguished in the Azure portal. " +
+					"The values are [Go templates](https://pkg.go.dev/text/template) that can reference `.Workspace`, the selected Terraform workspace, and `.ProviderVersion`, the version of this provider. " +
+					"The automatic tags are treated in the same way as `default_tags`, which override them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_tags": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags to assign to all the secrets managed by this provider. " +
+					"The `tags` of each resource override the default tags with the same keys.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault used by the resources and data sources that specify none of `key_vault_id`, `vault_name`, `vault_uri`, and `vault_alias`, " +
+					"so that stacks using only one Key Vault don't have to repeat it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"vault_aliases": schema.MapAttribute{
+				MarkdownDescription: "A mapping of logical names to the IDs of Key Vaults, such as `{ platform = azurerm_key_vault.platform.id }`. " +
+					"Resources and data sources can reference a Key Vault with `vault_alias` instead of `key_vault_id`, so that the Key Vaults can be swapped per environment in one place.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(keyVaultIDRegex, "")),
+				},
+			},
+			"recover_soft_deleted_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Whether to recover a soft-deleted secret with the same name and add a new version to it when creating a secret, " +
+					"instead of failing with `409 Conflict`. If the secret is being deleted, the recovery waits until the deletion completes. " +
+					"The recovered secret keeps its old versions and tags, even if `overwrite_existing` of the resource is `false`. " +
+					"This requires the `Microsoft.KeyVault/vaults/secrets/recover/action` permission. Defaults to `false`.",
+				Optional: true,
+			},
+			"name_pattern": schema.StringAttribute{
+				MarkdownDescription: "A regular expression that the names of all the secrets managed by this provider must match, such as `^[a-z0-9]+--(dev|stg|prd)--[a-z0-9-]+$`. " +
+					"A plan fails if the name of a resource doesn't match it, so that naming conventions are enforced before apply. " +
+					"The pattern is unanchored, so use `^` and `$` to match the whole name.",
+				Optional: true,
+			},
+			"required_tags": schema.ListAttribute{
+				MarkdownDescription: "A list of tag keys that all the secrets managed by this provider must have, such as `[\"owner\", \"env\"]`. " +
+					"A plan fails if the tags of a resource, including `default_tags`, lack any of them, so that tagging policies are enforced before apply.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"rbac_propagation_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies how long to retry creating a secret while Key Vault returns `403 ForbiddenByRbac`, such as `5m`. " +
+					"Role assignments take a few minutes to propagate, so this allows creating a Key Vault, its role assignments, and secrets in one apply. No retry is made by default.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"purge_wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "Specifies how long to retry creating a secret while Key Vault returns `409 ObjectIsBeingDeleted`, such as `5m`. " +
+					"Deleting or purging a secret takes a while, so this allows destroying and creating a secret with the same name in a row, such as in ephemeral environments. No retry is made by default.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
 			},
 		},
 	}
 }
 
 func (p *AzurekvProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// The configuration is unknown during plan if it refers to resources not created yet, such as subscription_id of another stack,
+	// so defer the resources and data sources of this provider instead of configuring the client with empty values.
+	// Terraform without deferred actions can't defer them, so report the unknown attributes instead.
+	if !req.Config.Raw.IsFullyKnown() {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		resp.Diagnostics.Append(unknownProviderConfigDiagnostics(req.Config.Raw)...)
+		return
+	}
+
 	var model AzurekvProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
@@ -58,25 +407,292 @@ func (p *AzurekvProvider) Configure(ctx context.Context, req provider.ConfigureR
 		}
 	}
 
-	c, err := NewClient(model.SubscriptionID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to Create Azure Client", err.Error())
+	var defaultTags map[string]string
+	resp.Diagnostics.Append(model.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+	var automaticTags map[string]string
+	resp.Diagnostics.Append(model.AutomaticTags.ElementsAs(ctx, &automaticTags, false)...)
+	var vaultAliases map[string]string
+	resp.Diagnostics.Append(model.VaultAliases.ElementsAs(ctx, &vaultAliases, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(automaticTags) > 0 {
+		workspace, err := terraformWorkspace()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("automatic_tags"), "Failed to Resolve Terraform Workspace", err.Error())
+			return
+		}
+		automaticTags, err = renderAutomaticTags(automaticTags, automaticTagData{Workspace: workspace, ProviderVersion: p.version})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("automatic_tags"), "Invalid Automatic Tags", err.Error())
+			return
+		}
+		defaultTags = mergeAutomaticTags(automaticTags, defaultTags)
+	}
+
+	var requiredTags []string
+	resp.Diagnostics.Append(model.RequiredTags.ElementsAs(ctx, &requiredTags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var namePattern *regexp.Regexp
+	if !model.NamePattern.IsNull() {
+		var err error
+		namePattern, err = regexp.Compile(model.NamePattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_pattern"), "Invalid Name Pattern", err.Error())
+			return
+		}
+	}
+
+	rbacPropagationTimeout, diags := durationFromConfig(model.RBACPropagationTimeout, path.Root("rbac_propagation_timeout"))
+	resp.Diagnostics.Append(diags...)
+	purgeWaitTimeout, diags := durationFromConfig(model.PurgeWaitTimeout, path.Root("purge_wait_timeout"))
+	resp.Diagnostics.Append(diags...)
+	dnsPropagationTimeout, diags := durationFromConfig(model.DNSPropagationTimeout, path.Root("dns_propagation_timeout"))
+	resp.Diagnostics.Append(diags...)
+	versionPropagationTimeout, diags := durationFromConfig(model.VersionPropagationTimeout, path.Root("version_propagation_timeout"))
+	resp.Diagnostics.Append(diags...)
+	requestTimeout, diags := durationFromConfig(model.RequestTimeout, path.Root("request_timeout"))
+	resp.Diagnostics.Append(diags...)
+	idleConnectionTimeout, diags := durationFromConfig(model.IdleConnectionTimeout, path.Root("idle_connection_timeout"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	if model.PartnerID.IsNull() {
+		if v := os.Getenv("ARM_PARTNER_ID"); v != "" {
+			if !partnerIDRegex.MatchString(v) {
+				resp.Diagnostics.AddError("Invalid Partner ID", fmt.Sprintf("ARM_PARTNER_ID must be a GUID/UUID optionally prefixed with pid-, got: %q", v))
+				return
+			}
+			model.PartnerID = types.StringValue(v)
+		}
+	}
+
+	caBundle := []byte(model.CABundlePEM.ValueString())
+	if !model.CABundleFile.IsNull() {
+		var err error
+		caBundle, err = os.ReadFile(model.CABundleFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_bundle_file"), "Failed to Read CA Bundle", err.Error())
+			return
+		}
+	}
+
+	var credential azcore.TokenCredential
+	if !model.AccessToken.IsNull() {
+		credential = &staticTokenCredential{token: model.AccessToken.ValueString()}
+	}
+
+	if model.MockMode.IsNull() {
+		if v := os.Getenv("AZUREKV_MOCK_MODE"); v != "" {
+			mockMode, err := strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("mock_mode"), "Invalid AZUREKV_MOCK_MODE", err.Error())
+				return
+			}
+			model.MockMode = types.BoolValue(mockMode)
+		}
+	}
+
+	var c Client
+	if model.MockMode.ValueBool() {
+		tflog.Warn(ctx, "mock_mode is enabled, so Azure is never called and applies are blocked")
+		c = newMockClient(model.SubscriptionID.ValueString())
+	} else {
+		var err error
+		c, err = NewClient(model.SubscriptionID.ValueString(), &ClientOptions{
+			DNSPropagationTimeout:     dnsPropagationTimeout,
+			VersionPropagationTimeout: versionPropagationTimeout,
+			RequestTimeout:            requestTimeout,
+			MaxRequestsPerSecond:      model.MaxRequestsPerSecond.ValueInt32(),
+			MaxConcurrentRequests:     model.MaxConcurrentRequests.ValueInt32(),
+			ProxyURL:                  model.ProxyURL.ValueString(),
+			CABundle:                  caBundle,
+			MinTLSVersion:             tlsVersions[model.MinTLSVersion.ValueString()],
+			UserAgentSuffix:           userAgentSuffix(model.PartnerID.ValueString(), model.UserAgentSuffix.ValueString()),
+			OTLPTracesEndpoint:        model.OTLPTracesEndpoint.ValueString(),
+			LoggedHeaders:             logHeaders(os.Getenv(LogHeadersEnvVar)),
+			MetricsFile:               model.MetricsFile.ValueString(),
+			AuditLogFile:              model.AuditLogFile.ValueString(),
+			RotationWebhookURL:        model.RotationWebhookURL.ValueString(),
+			EventGridTopicEndpoint:    model.EventGridTopicEndpoint.ValueString(),
+			EventGridTopicKey:         model.EventGridTopicKey.ValueString(),
+			VaultEndpoint:             model.CustomVaultEndpoint.ValueString(),
+			InsecureSkipVerify:        model.InsecureSkipTLSVerify.ValueBool(),
+			MaxIdleConns:              model.MaxIdleConnections.ValueInt32(),
+			IdleConnTimeout:           idleConnectionTimeout,
+			DisableKeepAlives:         model.DisableKeepAlives.ValueBool(),
+			DiscoverSubscriptionID:    model.DiscoverSubscriptionID.ValueBool(),
+			Credential:                credential,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Create Azure Client", err.Error())
+			return
+		}
+	}
+
+	p.clientsMutex.Lock()
+	p.clients = append(p.clients, c)
+	p.clientsMutex.Unlock()
+
+	data := &ProviderData{
+		Client:                    c,
+		PurgeSoftDeleteOnDestroy:  model.PurgeSoftDeleteOnDestroy.ValueBool(),
+		ExpirationWarningDays:     model.ExpirationWarningDays.ValueInt32(),
+		DefaultTags:               defaultTags,
+		VaultAliases:              vaultAliases,
+		DefaultKeyVaultID:         model.KeyVaultID.ValueString(),
+		RequiredTags:              requiredTags,
+		NamePattern:               namePattern,
+		RBACPropagationTimeout:    rbacPropagationTimeout,
+		PurgeWaitTimeout:          purgeWaitTimeout,
+		RecoverSoftDeletedSecrets: model.RecoverSoftDeletedSecrets.ValueBool(),
+		MockMode:                  model.MockMode.ValueBool(),
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
+	resp.ActionData = data
+	resp.ListResourceData = data
+}
+
+// ReportMetrics logs the summary of the API calls made by the clients of the provider and appends it to the metrics file if configured.
+// It should be called after the provider server stops.
+func (p *AzurekvProvider) ReportMetrics() {
+	p.clientsMutex.Lock()
+	clients := p.clients
+	p.clients = nil
+	p.clientsMutex.Unlock()
+
+	for _, c := range clients {
+		if err := c.ReportMetrics(); err != nil {
+			log.Print("[WARN] Failed to write the API call metrics: " + err.Error())
+		}
+	}
+}
+
+const (
+	tlsVersion12 = "1.2"
+	tlsVersion13 = "1.3"
+)
+
+var tlsVersions = map[string]uint16{
+	tlsVersion12: tls.VersionTLS12,
+	tlsVersion13: tls.VersionTLS13,
+}
+
+var customVaultEndpointRegex = regexp.MustCompile(`\Ahttps?://[^/\s]+`)
+
+var partnerIDRegex = regexp.MustCompile(`\A(?:pid-)?[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\z`)
+
+// userAgentSuffix returns the string appended to the user agent from the partner ID and the custom suffix.
+func userAgentSuffix(partnerID, suffix string) string {
+	var parts []string
+	if partnerID != "" {
+		if !strings.HasPrefix(partnerID, "pid-") {
+			partnerID = "pid-" + partnerID
+		}
+		parts = append(parts, partnerID)
+	}
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, " ")
+}
+
+// durationFromConfig returns the duration of the attribute, or zero if it is not configured.
+func durationFromConfig(value timetypes.GoDuration, p path.Path) (time.Duration, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, nil
+	}
+
+	d, diags := value.ValueGoDuration()
+	if diags.HasError() {
+		return 0, diags
+	}
+	if d < 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s must not be negative, got: %s", p, value.ValueString()),
+		)
+	}
+	return d, diags
+}
+
+// unknownProviderConfigDiagnostics returns an error for each attribute of the configuration containing unknown values.
+func unknownProviderConfigDiagnostics(config tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var attrs map[string]tftypes.Value
+	if err := config.As(&attrs); err != nil {
+		diags.AddError("Unknown Provider Configuration", "The provider configuration is unknown: "+err.Error())
+		return diags
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name, v := range attrs {
+		if !v.IsFullyKnown() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Unknown Provider Configuration Value",
+			fmt.Sprintf("The provider cannot create the client as there is an unknown configuration value for %s. "+
+				"Either apply the source of the value first, set the value statically in the configuration, "+
+				"or use a Terraform version that supports deferred actions.", name),
+		)
+	}
+	return diags
 }
 
 func (p *AzurekvProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewSecretResource,
+		NewReplicatedSecretResource,
+		NewKeyVaultSecretResource,
 	}
 }
 
 func (p *AzurekvProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSecretDataSource,
+		NewDeletedSecretDataSource,
+		NewFailoverSecretDataSource,
+		NewSecretStatisticsDataSource,
+		NewDataPlaneRolesDataSource,
+		NewKeyVaultDataSource,
+		NewVaultHealthDataSource,
+	}
+}
+
+func (p *AzurekvProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewBackupSecretAction,
+		NewSyncSecretAction,
+	}
+}
+
+func (p *AzurekvProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewCertificatePrivateKeyEphemeralResource,
+	}
+}
+
+func (p *AzurekvProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewKeyVaultListResource,
+		NewSecretListResource,
 	}
 }
 
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestProviderConfig returns the provider configuration with the attributes, leaving the others null.
+func newTestProviderConfig(t *testing.T, p provider.Provider, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+
+	typ := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	for name := range attrs {
+		if _, ok := typ.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown provider attribute %q", name)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(typ, values),
+	}
+}
+
+func TestProviderConfigureRecoverSoftDeletedSecrets(t *testing.T) {
+	tests := []struct {
+		name  string
+		value tftypes.Value
+		want  bool
+	}{
+		{
+			name:  "default",
+			value: tftypes.NewValue(tftypes.Bool, nil),
+			want:  false,
+		},
+		{
+			name:  "enabled",
+			value: tftypes.NewValue(tftypes.Bool, true),
+			want:  true,
+		},
+		{
+			name:  "disabled",
+			value: tftypes.NewValue(tftypes.Bool, false),
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New("test")()
+			var resp provider.ConfigureResponse
+			p.Configure(context.Background(), provider.ConfigureRequest{
+				Config: newTestProviderConfig(t, p, map[string]tftypes.Value{
+					"mock_mode":                    tftypes.NewValue(tftypes.Bool, true),
+					"recover_soft_deleted_secrets": tt.value,
+				}),
+			}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Configure() diagnostics = %v", resp.Diagnostics)
+			}
+
+			data, ok := resp.ResourceData.(*ProviderData)
+			if !ok {
+				t.Fatalf("ResourceData = %T, want *ProviderData", resp.ResourceData)
+			}
+			if data.RecoverSoftDeletedSecrets != tt.want {
+				t.Errorf("RecoverSoftDeletedSecrets = %v, want %v", data.RecoverSoftDeletedSecrets, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureUnknownValues(t *testing.T) {
+	attrs := map[string]tftypes.Value{
+		"mock_mode":       tftypes.NewValue(tftypes.Bool, true),
+		"subscription_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"default_tags":    tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue),
+	}
+
+	t.Run("deferral allowed", func(t *testing.T) {
+		p := New("test")()
+		var resp provider.ConfigureResponse
+		p.Configure(context.Background(), provider.ConfigureRequest{
+			Config:             newTestProviderConfig(t, p, attrs),
+			ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+		}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Configure() diagnostics = %v", resp.Diagnostics)
+		}
+		if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+			t.Errorf("Deferred = %v, want reason %v", resp.Deferred, provider.DeferredReasonProviderConfigUnknown)
+		}
+		if resp.ResourceData != nil {
+			t.Errorf("ResourceData = %v, want nil", resp.ResourceData)
+		}
+	})
+
+	t.Run("deferral not allowed", func(t *testing.T) {
+		p := New("test")()
+		var resp provider.ConfigureResponse
+		p.Configure(context.Background(), provider.ConfigureRequest{
+			Config: newTestProviderConfig(t, p, attrs),
+		}, &resp)
+		if resp.Deferred != nil {
+			t.Errorf("Deferred = %v, want nil", resp.Deferred)
+		}
+		if resp.ResourceData != nil {
+			t.Errorf("ResourceData = %v, want nil", resp.ResourceData)
+		}
+
+		want := []path.Path{path.Root("default_tags"), path.Root("subscription_id")}
+		errs := resp.Diagnostics.Errors()
+		if len(errs) != len(want) {
+			t.Fatalf("Configure() diagnostics = %v, want %d errors", resp.Diagnostics, len(want))
+		}
+		for i, d := range errs {
+			withPath, ok := d.(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(want[i]) {
+				t.Errorf("errors[%d] = %v, want an error for %s", i, d, want[i])
+			}
+			if d.Summary() != "Unknown Provider Configuration Value" {
+				t.Errorf("errors[%d].Summary() = %q, want %q", i, d.Summary(), "Unknown Provider Configuration Value")
+			}
+		}
+	})
+}
@@ -93,6 +93,15 @@ resource "azurerm_key_vault" "test" {
 `, config, randomName)
 }
 
+// keyVaultIDInConfig returns the ID of the Key Vault used by providersConfig,
+// which is useful for configurations without the resources, such as those of query steps.
+func keyVaultIDInConfig(randomName string) string {
+	if keyVaultID := os.Getenv("KEY_VAULT_ID"); keyVaultID != "" {
+		return keyVaultID
+	}
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/azurekv-acctest-%s/providers/Microsoft.KeyVault/vaults/%s", os.Getenv("ARM_SUBSCRIPTION_ID"), randomName, randomName)
+}
+
 func generateRandomName(n int) string {
 	prefix := time.Now().UTC().Format("F20060102T150405")
 	b := make([]rune, n-len(prefix))
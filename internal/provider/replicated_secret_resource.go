@@ -0,0 +1,645 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = (*ReplicatedSecretResource)(nil)
+var _ resource.ResourceWithConfigure = (*ReplicatedSecretResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*ReplicatedSecretResource)(nil)
+var _ resource.ResourceWithConfigValidators = (*ReplicatedSecretResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*ReplicatedSecretResource)(nil)
+
+func NewReplicatedSecretResource() resource.Resource {
+	return &ReplicatedSecretResource{}
+}
+
+// ReplicatedSecretResource defines the resource implementation.
+type ReplicatedSecretResource struct {
+	client                   Client
+	purgeSoftDeleteOnDestroy bool
+	defaultTags              map[string]string
+	requiredTags             []string
+	namePattern              *regexp.Regexp
+	recoverSoftDeleted       bool
+	purgeWaitTimeout         time.Duration
+	mockMode                 bool
+}
+
+type ReplicatedSecretResourceModel struct {
+	Name           types.String  `tfsdk:"name"`
+	KeyVaultIDs    types.Set     `tfsdk:"key_vault_ids"`
+	ValueWO        types.String  `tfsdk:"value_wo"`
+	ValueWOVersion types.Dynamic `tfsdk:"value_wo_version"`
+	ValueWOTrigger types.String  `tfsdk:"value_wo_trigger"`
+	Triggers       types.Map     `tfsdk:"triggers"`
+	ContentType    types.String  `tfsdk:"content_type"`
+	Tags           types.Map     `tfsdk:"tags"`
+	TagsAll        types.Map     `tfsdk:"tags_all"`
+	Vaults         types.Map     `tfsdk:"vaults"`
+}
+
+// ReplicatedSecretVaultModel describes the secret written to each Key Vault.
+type ReplicatedSecretVaultModel struct {
+	ID         types.String `tfsdk:"id"`
+	Version    types.String `tfsdk:"version"`
+	ResourceID types.String `tfsdk:"resource_id"`
+}
+
+var replicatedSecretVaultAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"version":     types.StringType,
+	"resource_id": types.StringType,
+}
+
+func (r *ReplicatedSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_replicated_secret"
+}
+
+func (r *ReplicatedSecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages Key Vault secrets with the same name and value in multiple Key Vaults, such as Key Vaults in different regions.\n\n" +
+			"Before writing the value, this resource verifies that all the Key Vaults are accessible, so that a misconfigured Key Vault doesn't leave the others updated alone. " +
+			"If writing to some Key Vaults still fails, the others are recorded in `vaults` and the failed ones are retried on the next apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Secrets. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_vault_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the Key Vaults where the Secrets should be created. " +
+					"Adding a Key Vault writes the current value to it, and removing a Key Vault deletes the secret from it.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(keyVaultIDRegex, "")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				MarkdownDescription: "Specifies the value of the Key Vault Secrets. Changing this will create a new version of the Key Vault Secrets.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"value_wo_version": schema.DynamicAttribute{
+				MarkdownDescription: "An integer or a string used to trigger an update for `value_wo`, such as a timestamp or an upstream revision. This property should be changed when updating `value_wo`. " +
+					"Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified.",
+				Optional: true,
+			},
+			"value_wo_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary string used to trigger an update for `value_wo`, such as a timestamp, a file hash, or an upstream version. " +
+					"Any change of this property updates `value_wo`. Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified.",
+				Optional: true,
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "A map of arbitrary strings used to trigger an update for `value_wo`, such as an application version and a certificate thumbprint. " +
+					"Any change of the entries updates `value_wo`. Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "Specifies the content type for the Key Vault Secrets.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags to assign to the Key Vault Secrets. These tags override the `default_tags` of the provider with the same keys. Up to 15 tags including the default tags can be assigned, and each key and value can have up to 512 and 256 characters respectively.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags assigned to the Key Vault Secrets, including the `default_tags` of the provider.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"vaults": schema.MapNestedAttribute{
+				MarkdownDescription: "The Key Vault Secrets written successfully, keyed by the IDs of the Key Vaults.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The Key Vault Secret ID.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The current version of the Key Vault Secret.",
+							Computed:            true,
+						},
+						"resource_id": schema.StringAttribute{
+							MarkdownDescription: "The (Versioned) ID for this Key Vault Secret.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ReplicatedSecretResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("value_wo_version"),
+			path.MatchRoot("value_wo_trigger"),
+			path.MatchRoot("triggers"),
+		),
+	}
+}
+
+func (r *ReplicatedSecretResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateValueWOVersion(ctx, config.ValueWOVersion)...)
+}
+
+func (r *ReplicatedSecretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.purgeSoftDeleteOnDestroy = data.PurgeSoftDeleteOnDestroy
+	r.defaultTags = data.DefaultTags
+	r.requiredTags = data.RequiredTags
+	r.namePattern = data.NamePattern
+	r.recoverSoftDeleted = data.RecoverSoftDeletedSecrets
+	r.purgeWaitTimeout = data.PurgeWaitTimeout
+	r.mockMode = data.MockMode
+}
+
+func (r *ReplicatedSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Fail up front because the checks before writing would fail on the fake secrets of the mock client
+	if r.mockMode {
+		resp.Diagnostics.AddError("Mock Mode Enabled", errMockMode.Error())
+		return
+	}
+
+	var model, config ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keyVaultIDs []string
+	resp.Diagnostics.Append(model.KeyVaultIDs.ElementsAs(ctx, &keyVaultIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.verifyKeyVaults(ctx, model.Name.ValueString(), keyVaultIDs, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := r.mergedTags(model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaults := make(map[string]ReplicatedSecretVaultModel, len(keyVaultIDs))
+	resp.Diagnostics.Append(r.setSecrets(ctx, model, config.ValueWO.ValueString(), tags, keyVaultIDs, vaults)...)
+
+	// Save the secrets written successfully even if some of them failed
+	model.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	model.Vaults, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: replicatedSecretVaultAttrTypes}, vaults)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ReplicatedSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the prior state so that the fake values of the mock client don't appear as changes on plan
+	if r.mockMode {
+		return
+	}
+
+	vaults, diags := replicatedSecretVaults(ctx, model.Vaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managedTags, diags := toMap(model.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The state saved before tags_all was added doesn't have it
+	if model.TagsAll.IsNull() {
+		model.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, mergeDefaultTags(r.defaultTags, managedTags))
+		resp.Diagnostics.Append(diags...)
+	}
+
+	name := model.Name.ValueString()
+	drifted := false
+	for _, keyVaultID := range slices.Sorted(maps.Keys(vaults)) {
+		secretProperties, err := r.client.GetSecretProperties(ctx, keyVaultID, name, "", nil)
+		if err != nil {
+			if isNotFoundError(err) {
+				// The secret is written again on the next apply
+				tflog.Warn(ctx, "The secret was not found in the key vault", map[string]any{"key_vault_id": keyVaultID})
+				delete(vaults, keyVaultID)
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Failed to Get Secret Properties",
+				fmt.Sprintf("An unexpected error occurred while getting secret properties in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionReadMetadata),
+			)
+			return
+		}
+
+		vaults[keyVaultID] = newReplicatedSecretVault(keyVaultID, secretProperties.ID)
+		if drifted {
+			continue
+		}
+
+		// Record the properties of the first key vault that differ from the state,
+		// so that the next apply updates the properties in all the key vaults
+		contentType := types.StringValue("")
+		if secretProperties.ContentType != nil {
+			contentType = types.StringValue(*secretProperties.ContentType)
+		}
+		tags := secretProperties.Tags
+		if tags == nil {
+			tags = map[string]*string{}
+		}
+		tagsAll, diags := types.MapValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !contentType.Equal(model.ContentType) || !tagsAll.Equal(model.TagsAll) {
+			tflog.Warn(ctx, "The properties of the secret differ from the state", map[string]any{"key_vault_id": keyVaultID})
+			model.ContentType = contentType
+			model.TagsAll = tagsAll
+			drifted = true
+		}
+	}
+
+	if len(vaults) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	model.Tags, diags = removeDefaultTags(ctx, model.TagsAll, managedTags, r.defaultTags)
+	resp.Diagnostics.Append(diags...)
+
+	model.Vaults, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: replicatedSecretVaultAttrTypes}, vaults)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ReplicatedSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Fail up front because the checks before writing would fail on the fake secrets of the mock client
+	if r.mockMode {
+		resp.Diagnostics.AddError("Mock Mode Enabled", errMockMode.Error())
+		return
+	}
+
+	var model, config, state ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keyVaultIDs []string
+	resp.Diagnostics.Append(model.KeyVaultIDs.ElementsAs(ctx, &keyVaultIDs, false)...)
+	vaults, diags := replicatedSecretVaults(ctx, state.Vaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+	valueChanged, _ := config.valueWOTriggers().changedFrom(state.valueWOTriggers())
+	// Compare the tags including the default tags, which may be changed without changing tags
+	propertiesChanged := !model.ContentType.Equal(state.ContentType) || !model.TagsAll.Equal(state.TagsAll)
+
+	var toSet, toUpdate []string
+	for _, keyVaultID := range keyVaultIDs {
+		_, ok := vaults[keyVaultID]
+		switch {
+		case valueChanged || !ok:
+			toSet = append(toSet, keyVaultID)
+		case propertiesChanged:
+			toUpdate = append(toUpdate, keyVaultID)
+		}
+	}
+
+	resp.Diagnostics.Append(r.verifyKeyVaults(ctx, name, toSet, vaults)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for keyVaultID := range vaults {
+		if !slices.Contains(keyVaultIDs, keyVaultID) {
+			if diags := r.deleteSecret(ctx, keyVaultID, name); diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				continue
+			}
+			delete(vaults, keyVaultID)
+		}
+	}
+
+	tags, diags := r.mergedTags(model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setSecrets(ctx, model, config.ValueWO.ValueString(), tags, toSet, vaults)...)
+
+	for _, keyVaultID := range toUpdate {
+		updateResp, err := r.client.UpdateSecretProperties(ctx, keyVaultID, name, vaults[keyVaultID].Version.ValueString(), azsecrets.UpdateSecretPropertiesParameters{
+			ContentType: model.ContentType.ValueStringPointer(),
+			Tags:        tags,
+		}, nil)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Update Secret Properties",
+				fmt.Sprintf("An unexpected error occurred while updating secret properties in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionUpdate),
+			)
+			continue
+		}
+		vaults[keyVaultID] = newReplicatedSecretVault(keyVaultID, updateResp.ID)
+	}
+
+	// Save the secrets written successfully even if some of them failed
+	model.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	model.Vaults, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: replicatedSecretVaultAttrTypes}, vaults)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *ReplicatedSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vaults, diags := replicatedSecretVaults(ctx, state.Vaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for keyVaultID := range vaults {
+		resp.Diagnostics.Append(r.deleteSecret(ctx, keyVaultID, state.Name.ValueString())...)
+	}
+}
+
+func (r *ReplicatedSecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Config.Raw.IsNull() { // This resource will be deleted
+		return
+	}
+
+	var plan, state ReplicatedSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Name.IsUnknown() {
+		if err := checkNamePattern(r.namePattern, plan.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Secret Name", err.Error())
+			return
+		}
+	}
+
+	modifyTagsAllPlan(ctx, resp, r.defaultTags, r.requiredTags, false)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() || plan.KeyVaultIDs.IsUnknown() { // This resource will be created
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keyVaultIDs []string
+	resp.Diagnostics.Append(plan.KeyVaultIDs.ElementsAs(ctx, &keyVaultIDs, false)...)
+	vaults, diags := replicatedSecretVaults(ctx, state.Vaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retry the Key Vaults where writing or deleting the secret failed
+	inSync := len(keyVaultIDs) == len(vaults)
+	for _, keyVaultID := range keyVaultIDs {
+		if _, ok := vaults[keyVaultID]; !ok {
+			inSync = false
+		}
+	}
+	if !inSync {
+		tflog.Debug(ctx, "The secrets will be updated because the key vaults differ from those where the secret is written")
+		resp.Plan.SetAttribute(ctx, path.Root("vaults"), types.MapUnknown(types.ObjectType{AttrTypes: replicatedSecretVaultAttrTypes}))
+	}
+}
+
+// verifyKeyVaults verifies that the secret can be written to all the key vaults before writing any of them.
+// The secret must not exist in the key vaults other than those in vaults, which are managed by this resource.
+func (r *ReplicatedSecretResource) verifyKeyVaults(ctx context.Context, name string, keyVaultIDs []string, vaults map[string]ReplicatedSecretVaultModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, keyVaultID := range keyVaultIDs {
+		existing, err := r.client.GetSecretProperties(ctx, keyVaultID, name, "", nil)
+		if err != nil {
+			if !isNotFoundError(err) {
+				diags.AddError(
+					"Failed to Get Secret Properties",
+					fmt.Sprintf("An unexpected error occurred while verifying the key vault %q, so no secrets were written: %s", keyVaultID, err)+azureErrorHint(err, dataActionReadMetadata),
+				)
+			}
+			continue
+		}
+
+		if _, ok := vaults[keyVaultID]; !ok {
+			diags.AddError(
+				"Secret Already Exists",
+				fmt.Sprintf("The secret %q already exists, so no secrets were written. Delete it or remove its key vault from key_vault_ids.", *existing.ID),
+			)
+		}
+	}
+
+	return diags
+}
+
+// maxConcurrentReplicaWrites is the maximum number of key vaults to which a secret is written concurrently.
+// The requests are also subject to max_requests_per_second and max_concurrent_requests of the provider,
+// and the throttled ones are retried by the retry policy of the client.
+const maxConcurrentReplicaWrites = 16
+
+// setSecrets writes the value to the key vaults concurrently and records the results in vaults.
+func (r *ReplicatedSecretResource) setSecrets(ctx context.Context, model ReplicatedSecretResourceModel, value string, tags map[string]*string, keyVaultIDs []string, vaults map[string]ReplicatedSecretVaultModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Each worker writes only its own element, so that the results are collected in the order of keyVaultIDs without locks
+	ids := make([]*azsecrets.ID, len(keyVaultIDs))
+	errs := make([]error, len(keyVaultIDs))
+	var g errgroup.Group
+	g.SetLimit(maxConcurrentReplicaWrites)
+	for i, keyVaultID := range keyVaultIDs {
+		g.Go(func() error {
+			parameters := azsecrets.SetSecretParameters{
+				Value:       to.Ptr(value),
+				ContentType: model.ContentType.ValueStringPointer(),
+				Tags:        tags,
+			}
+			var setResp azsecrets.SetSecretResponse
+			// A vault removed from key_vault_ids may still have the secret being deleted or purged by the previous apply
+			errs[i] = retryWhileBeingDeleted(ctx, r.purgeWaitTimeout, defaultPollInterval, func() (err error) {
+				if r.recoverSoftDeleted {
+					// A vault removed from key_vault_ids may still have the secret soft-deleted
+					setResp, err = setSecretRecoveringDeleted(ctx, r.client, keyVaultID, model.Name.ValueString(), parameters, nil)
+				} else {
+					setResp, err = r.client.SetSecret(ctx, keyVaultID, model.Name.ValueString(), parameters, nil)
+				}
+				return err
+			})
+			ids[i] = setResp.ID
+			// The errors are reported as diagnostics, so that the other writes are not canceled
+			return nil
+		})
+	}
+	g.Wait()
+
+	for i, keyVaultID := range keyVaultIDs {
+		if err := errs[i]; err != nil {
+			diags.AddError(
+				"Failed to Set Secret",
+				fmt.Sprintf("An unexpected error occurred while setting a secret in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionSetSecret),
+			)
+			continue
+		}
+
+		tflog.Debug(ctx, "Set the secret", map[string]any{"key_vault_id": keyVaultID, "id": string(*ids[i])})
+		vaults[keyVaultID] = newReplicatedSecretVault(keyVaultID, ids[i])
+	}
+
+	return diags
+}
+
+func (r *ReplicatedSecretResource) deleteSecret(ctx context.Context, keyVaultID, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := r.client.DeleteSecret(ctx, keyVaultID, name, nil); err != nil {
+		if isNotFoundError(err) {
+			return diags
+		}
+		diags.AddError(
+			"Failed to Delete Secret",
+			fmt.Sprintf("An unexpected error occurred while deleting a secret in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionDelete),
+		)
+		return diags
+	}
+
+	if !r.purgeSoftDeleteOnDestroy {
+		return diags
+	}
+
+	if _, err := r.client.PurgeDeletedSecret(ctx, keyVaultID, name, nil); err != nil {
+		diags.AddError(
+			"Failed to Purge Secret",
+			fmt.Sprintf("The secret was deleted, but an unexpected error occurred while purging it in the key vault %q: %s", keyVaultID, err)+azureErrorHint(err, dataActionPurge),
+		)
+	}
+
+	return diags
+}
+
+// mergedTags returns the tags of the model merged with the default tags of the provider.
+func (r *ReplicatedSecretResource) mergedTags(model ReplicatedSecretResourceModel) (map[string]*string, diag.Diagnostics) {
+	tags, diags := toMap(model.Tags)
+	return mergeDefaultTags(r.defaultTags, tags), diags
+}
+
+func (m ReplicatedSecretResourceModel) valueWOTriggers() valueWOTriggers {
+	return valueWOTriggers{version: m.ValueWOVersion, trigger: m.ValueWOTrigger, triggers: m.Triggers}
+}
+
+func newReplicatedSecretVault(keyVaultID string, id *azsecrets.ID) ReplicatedSecretVaultModel {
+	return ReplicatedSecretVaultModel{
+		ID:         types.StringValue(string(*id)),
+		Version:    types.StringValue(id.Version()),
+		ResourceID: types.StringValue(keyVaultID + "/secrets/" + id.Name() + "/versions/" + id.Version()),
+	}
+}
+
+func replicatedSecretVaults(ctx context.Context, m types.Map) (map[string]ReplicatedSecretVaultModel, diag.Diagnostics) {
+	vaults := make(map[string]ReplicatedSecretVaultModel)
+	if m.IsNull() || m.IsUnknown() {
+		return vaults, nil
+	}
+	diags := m.ElementsAs(ctx, &vaults, false)
+	return vaults, diags
+}
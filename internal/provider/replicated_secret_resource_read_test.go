@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	azsecretsfake "github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets/fake"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestReplicatedSecretResourceRead(t *testing.T) {
+	t.Parallel()
+
+	defaultTags := map[string]string{"owner": "platform"}
+
+	tests := []struct {
+		name            string
+		contentType     *string
+		tags            map[string]*string
+		stateTagsAll    types.Map
+		wantContentType types.String
+		wantTags        types.Map
+		wantTagsAll     types.Map
+	}{
+		{
+			name:            "in sync",
+			contentType:     to.Ptr("text/plain"),
+			tags:            map[string]*string{"owner": to.Ptr("platform"), "environment": to.Ptr("test")},
+			stateTagsAll:    stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+			wantContentType: types.StringValue("text/plain"),
+			wantTags:        stringMap(map[string]string{"environment": "test"}),
+			wantTagsAll:     stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+		},
+		{
+			name:            "state without tags_all",
+			contentType:     to.Ptr("text/plain"),
+			tags:            map[string]*string{"owner": to.Ptr("platform"), "environment": to.Ptr("test")},
+			stateTagsAll:    types.MapNull(types.StringType),
+			wantContentType: types.StringValue("text/plain"),
+			wantTags:        stringMap(map[string]string{"environment": "test"}),
+			wantTagsAll:     stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+		},
+		{
+			name:            "content type changed outside of Terraform",
+			contentType:     to.Ptr("application/json"),
+			tags:            map[string]*string{"owner": to.Ptr("platform"), "environment": to.Ptr("test")},
+			stateTagsAll:    stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+			wantContentType: types.StringValue("application/json"),
+			wantTags:        stringMap(map[string]string{"environment": "test"}),
+			wantTagsAll:     stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+		},
+		{
+			name:            "tags changed outside of Terraform",
+			contentType:     to.Ptr("text/plain"),
+			tags:            map[string]*string{"owner": to.Ptr("someone"), "environment": to.Ptr("prod")},
+			stateTagsAll:    stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+			wantContentType: types.StringValue("text/plain"),
+			wantTags:        stringMap(map[string]string{"owner": "someone", "environment": "prod"}),
+			wantTagsAll:     stringMap(map[string]string{"owner": "someone", "environment": "prod"}),
+		},
+		{
+			name:            "all tags removed outside of Terraform",
+			contentType:     to.Ptr("text/plain"),
+			stateTagsAll:    stringMap(map[string]string{"owner": "platform", "environment": "test"}),
+			wantContentType: types.StringValue("text/plain"),
+			wantTags:        stringMap(map[string]string{}),
+			wantTagsAll:     stringMap(map[string]string{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeServer := azsecretsfake.Server{
+				NewListSecretPropertiesVersionsPager: func(
+					_ string,
+					_ *azsecrets.ListSecretPropertiesVersionsOptions,
+				) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+					properties := secretProperties("version-1", 100)
+					properties.ContentType = tt.contentType
+					properties.Tags = tt.tags
+					resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+						SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{
+							Value: []*azsecrets.SecretProperties{properties},
+						},
+					}, nil)
+					return
+				},
+			}
+
+			ctx := t.Context()
+			r := &ReplicatedSecretResource{client: newTestClient(t, &fakeServer), defaultTags: defaultTags}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			vaults := map[string]ReplicatedSecretVaultModel{
+				testKeyVaultID: newReplicatedSecretVault(testKeyVaultID, to.Ptr(azsecrets.ID(testVaultURL+"/secrets/secret-name/version-1"))),
+			}
+			vaultsValue, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: replicatedSecretVaultAttrTypes}, vaults)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			diags = state.Set(ctx, ReplicatedSecretResourceModel{
+				Name:           types.StringValue("secret-name"),
+				KeyVaultIDs:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(testKeyVaultID)}),
+				ValueWO:        types.StringNull(),
+				ValueWOVersion: types.DynamicValue(types.Int64Value(1)),
+				ValueWOTrigger: types.StringNull(),
+				Triggers:       types.MapNull(types.StringType),
+				ContentType:    types.StringValue("text/plain"),
+				Tags:           stringMap(map[string]string{"environment": "test"}),
+				TagsAll:        tt.stateTagsAll,
+				Vaults:         vaultsValue,
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var got ReplicatedSecretResourceModel
+			if diags := resp.State.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !got.ContentType.Equal(tt.wantContentType) {
+				t.Errorf("content_type = %v, want %v", got.ContentType, tt.wantContentType)
+			}
+			if !got.Tags.Equal(tt.wantTags) {
+				t.Errorf("tags = %v, want %v", got.Tags, tt.wantTags)
+			}
+			if !got.TagsAll.Equal(tt.wantTagsAll) {
+				t.Errorf("tags_all = %v, want %v", got.TagsAll, tt.wantTagsAll)
+			}
+		})
+	}
+}
+
+func stringMap(m map[string]string) types.Map {
+	values := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		values[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
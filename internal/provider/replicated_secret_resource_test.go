@@ -0,0 +1,194 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccReplicatedSecretResource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: replicatedSecretResourceConfig(rn, 1),
+				Check:  resource.TestCheckResourceAttr("azurekv_replicated_secret.test", "vaults.%", "1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: replicatedSecretResourceConfig(rn, 2),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccReplicatedSecretResource_valueWOVersionString(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: replicatedSecretTriggerResourceConfig(rn, "value_wo_version", "2025-01-23T00:00:00Z"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: replicatedSecretTriggerResourceConfig(rn, "value_wo_version", "2025-02-23T00:00:00Z"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccReplicatedSecretResource_valueWOTrigger(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: replicatedSecretTriggerResourceConfig(rn, "value_wo_trigger", "2025-01-23"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: replicatedSecretTriggerResourceConfig(rn, "value_wo_trigger", "2025-02-23"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("data.azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccReplicatedSecretResource_existing(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "existing" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = "secret-value"
+}
+
+resource "azurekv_replicated_secret" "test" {
+  name          = azurerm_key_vault_secret.existing.name
+  key_vault_ids = [azurerm_key_vault_secret.existing.key_vault_id]
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+}
+`, providersConfig(rn), rn),
+				ExpectError: regexp.MustCompile("Secret Already Exists"),
+			},
+		},
+	})
+}
+
+func replicatedSecretResourceConfig(resourceSuffix string, version int) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_replicated_secret" "test" {
+  name          = "secret-name-%s"
+  key_vault_ids = [local.key_vault_id]
+
+  value_wo         = "secret-value"
+  value_wo_version = %d
+
+  tags = {
+    environment = "test"
+  }
+}
+
+data "azurekv_secret" "test" {
+  name         = azurekv_replicated_secret.test.name
+  key_vault_id = local.key_vault_id
+
+  depends_on = [azurekv_replicated_secret.test]
+}
+`, providersConfig(resourceSuffix), resourceSuffix, version)
+}
+
+func replicatedSecretTriggerResourceConfig(resourceSuffix, attribute, trigger string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_replicated_secret" "test" {
+  name          = "secret-name-%s"
+  key_vault_ids = [local.key_vault_id]
+
+  value_wo = "secret-value"
+  %s = %q
+}
+
+data "azurekv_secret" "test" {
+  name         = azurekv_replicated_secret.test.name
+  key_vault_id = local.key_vault_id
+
+  depends_on = [azurekv_replicated_secret.test]
+}
+`, providersConfig(resourceSuffix), resourceSuffix, attribute, trigger)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// RotationEvent is posted to the rotation webhook as JSON when a new version of an existing secret is created.
+// Creating a secret is not a rotation, so it posts no event. Secret values are never included.
+type RotationEvent struct {
+	Time            string `json:"time"`
+	KeyVaultID      string `json:"key_vault_id"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+	ExpirationDate  string `json:"expiration_date,omitempty"`
+}
+
+// rotationWebhook posts rotation events to a URL, so that dependent services can react to rotations without polling Key Vault.
+type rotationWebhook struct {
+	url      string
+	pipeline runtime.Pipeline
+}
+
+// newRotationWebhook returns nil if url is empty, which means no notification.
+func newRotationWebhook(url string, pipeline runtime.Pipeline) *rotationWebhook {
+	if url == "" {
+		return nil
+	}
+
+	return &rotationWebhook{
+		url:      url,
+		pipeline: pipeline,
+	}
+}
+
+// notify posts the event, and returns an error if the webhook doesn't respond with a 2xx status.
+func (w *rotationWebhook) notify(ctx context.Context, event RotationEvent) error {
+	if w == nil {
+		return nil
+	}
+
+	if event.Time == "" {
+		event.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	return postJSON(ctx, w.pipeline, w.url, nil, event)
+}
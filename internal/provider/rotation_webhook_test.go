@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestRotationWebhookNotify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "failure",
+			statusCode: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got RotationEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("got method %s, want %s", r.Method, http.MethodPost)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("got Content-Type %q, want %q", ct, "application/json")
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode the body: %v", err)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			webhook := newRotationWebhook(server.URL, newNotificationPipeline(&arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: server.Client()}}))
+			err := webhook.notify(context.Background(), RotationEvent{
+				KeyVaultID:      testKeyVaultID,
+				Name:            "secret-name",
+				Version:         "version-2",
+				PreviousVersion: "version-1",
+				ExpirationDate:  "2030-01-01T00:00:00Z",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got.Time == "" {
+				t.Error("Time is empty")
+			}
+			want := RotationEvent{
+				Time:            got.Time,
+				KeyVaultID:      testKeyVaultID,
+				Name:            "secret-name",
+				Version:         "version-2",
+				PreviousVersion: "version-1",
+				ExpirationDate:  "2030-01-01T00:00:00Z",
+			}
+			if got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRotationWebhookNotifyDisabled(t *testing.T) {
+	t.Parallel()
+
+	webhook := newRotationWebhook("", runtime.Pipeline{})
+	if webhook != nil {
+		t.Fatalf("newRotationWebhook() = %v, want nil", webhook)
+	}
+	if err := webhook.notify(context.Background(), RotationEvent{}); err != nil {
+		t.Errorf("notify() error = %v", err)
+	}
+}
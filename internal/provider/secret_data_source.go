@@ -2,11 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -14,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = (*SecretDataSource)(nil)
 var _ datasource.DataSourceWithConfigure = (*SecretDataSource)(nil)
+var _ datasource.DataSourceWithConfigValidators = (*SecretDataSource)(nil)
 
 func NewSecretDataSource() datasource.DataSource {
 	return &SecretDataSource{}
@@ -21,12 +30,17 @@ func NewSecretDataSource() datasource.DataSource {
 
 // SecretDataSource defines the data source implementation.
 type SecretDataSource struct {
-	client Client
+	client            Client
+	vaultAliases      map[string]string
+	defaultKeyVaultID string
 }
 
 type SecretDataSourceModel struct {
 	Name                  types.String      `tfsdk:"name"`
 	KeyVaultID            types.String      `tfsdk:"key_vault_id"`
+	VaultName             types.String      `tfsdk:"vault_name"`
+	VaultURI              types.String      `tfsdk:"vault_uri"`
+	VaultAlias            types.String      `tfsdk:"vault_alias"`
 	ID                    types.String      `tfsdk:"id"`
 	VersionlessID         types.String      `tfsdk:"versionless_id"`
 	ContentType           types.String      `tfsdk:"content_type"`
@@ -40,6 +54,48 @@ type SecretDataSourceModel struct {
 
 var _ SecretModel = (*SecretDataSourceModel)(nil)
 
+// SecretDataSourceMetadataModel adds the attributes that only the data source has, such as the metadata of the version,
+// since SecretDataSourceModel is shared by the resource.
+type SecretDataSourceMetadataModel struct {
+	SecretDataSourceModel
+	OnlyEnabled     types.Bool        `tfsdk:"only_enabled"`
+	CreatedDate     timetypes.RFC3339 `tfsdk:"created_date"`
+	UpdatedDate     timetypes.RFC3339 `tfsdk:"updated_date"`
+	Enabled         types.Bool        `tfsdk:"enabled"`
+	RecoveryLevel   types.String      `tfsdk:"recovery_level"`
+	RecoverableDays types.Int32       `tfsdk:"recoverable_days"`
+}
+
+// setMetadata sets the metadata attributes from the attributes of the secret.
+func (s *SecretDataSourceMetadataModel) setMetadata(attrs *azsecrets.SecretAttributes) {
+	s.CreatedDate = timetypes.NewRFC3339Null()
+	if attrs.Created != nil {
+		s.CreatedDate = timetypes.NewRFC3339TimeValue(attrs.Created.UTC())
+	}
+	s.UpdatedDate = timetypes.NewRFC3339Null()
+	if attrs.Updated != nil {
+		s.UpdatedDate = timetypes.NewRFC3339TimeValue(attrs.Updated.UTC())
+	}
+	s.Enabled = types.BoolPointerValue(attrs.Enabled)
+	s.RecoveryLevel = types.StringPointerValue(attrs.RecoveryLevel)
+	s.RecoverableDays = types.Int32PointerValue(attrs.RecoverableDays)
+}
+
+// resolveKeyVaultID returns the ID of the key vault specified by vault_alias, vault_name, or vault_uri,
+// or defaultKeyVaultID if none of them is specified.
+func (s *SecretDataSourceModel) resolveKeyVaultID(ctx context.Context, client Client, vaultAliases map[string]string, defaultKeyVaultID string) (string, error) {
+	if s.VaultAlias.IsNull() && s.VaultName.IsNull() && s.VaultURI.IsNull() {
+		if defaultKeyVaultID == "" {
+			return "", errMissingKeyVault
+		}
+		return defaultKeyVaultID, nil
+	}
+	if !s.VaultAlias.IsNull() {
+		return resolveVaultAlias(vaultAliases, s.VaultAlias.ValueString())
+	}
+	return resolveKeyVaultID(ctx, client, s.VaultName.ValueString(), s.VaultURI.ValueString())
+}
+
 func (s *SecretDataSourceModel) GetKeyVaultID() string {
 	return s.KeyVaultID.ValueString()
 }
@@ -96,13 +152,46 @@ func (d *SecretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Required:            true,
 			},
 			"key_vault_id": schema.StringAttribute{
-				MarkdownDescription: "Specifies the ID of the Key Vault instance to fetch secret names from, available on the `azurerm_key_vault` Data Source / Resource.",
-				Required:            true,
+				MarkdownDescription: "Specifies the ID of the Key Vault instance to fetch secret names from, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+			},
+			"vault_name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault instance to fetch secret names from. " +
+					"The ID of the Key Vault is resolved with the `Microsoft.KeyVault/vaults/read` permission in the subscription of the provider. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vaultNameRegex, ""),
+				},
+			},
+			"vault_uri": schema.StringAttribute{
+				MarkdownDescription: "Specifies the URI of the Key Vault instance to fetch secret names from, such as `https://example.vault.azure.net/`. " +
+					"The ID of the Key Vault is resolved with the `Microsoft.KeyVault/vaults/read` permission in the subscription of the provider. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vaultURIRegex, ""),
+				},
+			},
+			"vault_alias": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault instance in `vault_aliases` of the provider to fetch secret names from. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
 			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "Specifies the version of the Key Vault Secret. Defaults to the current version of the Key Vault Secret.",
 				Optional:            true,
 			},
+			"only_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to resolve the newest version that is enabled and whose `not_before_date` and `expiration_date` include the current time, " +
+					"instead of the current version, which may be disabled or expired. Conflicts with `version`. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("version")),
+				},
+			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The Key Vault Secret ID.",
 				Computed:            true,
@@ -138,30 +227,65 @@ func (d *SecretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"created_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the version of the Key Vault Secret was created.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"updated_date": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the version of the Key Vault Secret was last updated.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the version of the Key Vault Secret is enabled.",
+				Computed:            true,
+			},
+			"recovery_level": schema.StringAttribute{
+				MarkdownDescription: "The deletion recovery level of the Key Vault Secret, such as `Recoverable+Purgeable`, which reflects the soft-delete and purge protection settings of the Key Vault.",
+				Computed:            true,
+			},
+			"recoverable_days": schema.Int32Attribute{
+				MarkdownDescription: "The number of days for which the Key Vault Secret is retained after deletion, which is the soft-delete retention period of the Key Vault.",
+				Computed:            true,
+			},
 		},
 	}
 }
 
+func (d *SecretDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("key_vault_id"),
+			path.MatchRoot("vault_name"),
+			path.MatchRoot("vault_uri"),
+			path.MatchRoot("vault_alias"),
+		),
+	}
+}
+
 func (d *SecretDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Configure Type",
-			fmt.Sprintf("Expected Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = data.Client
+	d.vaultAliases = data.VaultAliases
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
 }
 
 func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var model SecretDataSourceModel
+	var model SecretDataSourceMetadataModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
 	if resp.Diagnostics.HasError() {
@@ -170,22 +294,54 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	ctx = tflog.SetField(ctx, LogKeyResourceID, model.ID.ValueString())
 
-	secretProperties, err := d.client.GetSecretProperties(
-		ctx,
-		model.KeyVaultID.ValueString(),
-		model.Name.ValueString(),
-		model.Version.ValueString(),
-		nil,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error())
-		return
+	if model.KeyVaultID.IsNull() {
+		keyVaultID, err := model.resolveKeyVaultID(ctx, d.client, d.vaultAliases, d.defaultKeyVaultID)
+		if errors.Is(err, errMissingKeyVault) {
+			resp.Diagnostics.AddError("Missing Key Vault", "One of key_vault_id, vault_name, vault_uri, or vault_alias must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Get KeyVaults", err.Error()+azureRequestDetails(err))
+			return
+		}
+		model.KeyVaultID = types.StringValue(keyVaultID)
+	}
+
+	var secretProperties *azsecrets.SecretProperties
+	if model.OnlyEnabled.ValueBool() {
+		versions, err := d.client.ListSecretPropertiesVersions(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to List Secret Versions", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
+		secretProperties = latestUsableVersion(versions, time.Now())
+		if secretProperties == nil {
+			resp.Diagnostics.AddError(
+				"No Usable Secret Version",
+				fmt.Sprintf("The secret %q has no version that is enabled and within its activation and expiration dates.", model.Name.ValueString()),
+			)
+			return
+		}
+	} else {
+		var err error
+		secretProperties, err = d.client.GetSecretProperties(
+			ctx,
+			model.KeyVaultID.ValueString(),
+			model.Name.ValueString(),
+			model.Version.ValueString(),
+			nil,
+		)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
 	}
 
 	resp.Diagnostics.Append(setSecretData(&model, secretProperties.ID, secretProperties.Attributes, secretProperties.ContentType, secretProperties.Tags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	model.setMetadata(secretProperties.Attributes)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
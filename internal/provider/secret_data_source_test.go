@@ -24,19 +24,25 @@ func TestAccSecretDataSource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: basicDataSourceConfig(rn),
-				Check: testCheckResourceAttrPairs("data.azurekv_secret.test", "azurerm_key_vault_secret.test", []string{
-					"content_type",
-					"expiration_date",
-					"id",
-					"key_vault_id",
-					"name",
-					"not_before_date",
-					"resource_id",
-					"resource_versionless_id",
-					"tags",
-					"version",
-					"versionless_id",
-				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckResourceAttrPairs("data.azurekv_secret.test", "azurerm_key_vault_secret.test", []string{
+						"content_type",
+						"expiration_date",
+						"id",
+						"key_vault_id",
+						"name",
+						"not_before_date",
+						"resource_id",
+						"resource_versionless_id",
+						"tags",
+						"version",
+						"versionless_id",
+					}),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret.test", "created_date"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret.test", "updated_date"),
+					resource.TestCheckResourceAttr("data.azurekv_secret.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret.test", "recovery_level"),
+				),
 			},
 		},
 	})
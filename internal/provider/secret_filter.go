@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretFilterModel is the filter attributes shared by the listings of secrets.
+type secretFilterModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	NameGlob   types.String `tfsdk:"name_glob"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+	Tags       types.Map    `tfsdk:"tags"`
+}
+
+// secretFilter selects the listed secrets, so that a key vault shared by multiple applications can be sliced per application.
+// The zero value matches all the secrets.
+type secretFilter struct {
+	namePrefix string
+	nameGlob   string
+	nameRegex  *regexp.Regexp
+	tags       map[string]string
+}
+
+// newSecretFilter returns the filter of the attributes.
+func newSecretFilter(ctx context.Context, model secretFilterModel) (secretFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filter := secretFilter{
+		namePrefix: model.NamePrefix.ValueString(),
+		nameGlob:   model.NameGlob.ValueString(),
+	}
+
+	if _, err := path.Match(filter.nameGlob, ""); err != nil {
+		diags.AddAttributeError(tfpath.Root("name_glob"), "Invalid Name Glob", err.Error())
+	}
+	if !model.NameRegex.IsNull() {
+		var err error
+		filter.nameRegex, err = regexp.Compile(model.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(tfpath.Root("name_regex"), "Invalid Name Regex", err.Error())
+		}
+	}
+	if !model.Tags.IsNull() {
+		diags.Append(model.Tags.ElementsAs(ctx, &filter.tags, false)...)
+	}
+
+	return filter, diags
+}
+
+// match returns whether the secret satisfies all the filters.
+func (f secretFilter) match(secret *azsecrets.SecretProperties) bool {
+	name := secret.ID.Name()
+	if !strings.HasPrefix(name, f.namePrefix) {
+		return false
+	}
+	if f.nameGlob != "" {
+		if ok, _ := path.Match(f.nameGlob, name); !ok {
+			return false
+		}
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+	for k, v := range f.tags {
+		if value, ok := secret.Tags[k]; !ok || value == nil || *value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	secret := &azsecrets.SecretProperties{
+		ID:   to.Ptr(azsecrets.ID(testVaultURL + "/secrets/app-a-password")),
+		Tags: map[string]*string{"app": to.Ptr("a"), "env": to.Ptr("prod")},
+	}
+
+	tests := []struct {
+		name  string
+		model secretFilterModel
+		want  bool
+	}{
+		{
+			name: "no filters",
+			want: true,
+		},
+		{
+			name:  "matching name_prefix",
+			model: secretFilterModel{NamePrefix: types.StringValue("app-a-")},
+			want:  true,
+		},
+		{
+			name:  "non-matching name_prefix",
+			model: secretFilterModel{NamePrefix: types.StringValue("app-b-")},
+		},
+		{
+			name:  "matching name_glob",
+			model: secretFilterModel{NameGlob: types.StringValue("app-*-password")},
+			want:  true,
+		},
+		{
+			name:  "non-matching name_glob",
+			model: secretFilterModel{NameGlob: types.StringValue("app-*-token")},
+		},
+		{
+			name:  "matching name_regex",
+			model: secretFilterModel{NameRegex: types.StringValue(`^app-[a-z]-`)},
+			want:  true,
+		},
+		{
+			name:  "non-matching name_regex",
+			model: secretFilterModel{NameRegex: types.StringValue(`^app-[0-9]-`)},
+		},
+		{
+			name: "matching tags",
+			model: secretFilterModel{Tags: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"app": types.StringValue("a"),
+			})},
+			want: true,
+		},
+		{
+			name: "tag with a different value",
+			model: secretFilterModel{Tags: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"app": types.StringValue("a"),
+				"env": types.StringValue("dev"),
+			})},
+		},
+		{
+			name: "missing tag",
+			model: secretFilterModel{Tags: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"team": types.StringValue("a"),
+			})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, diags := newSecretFilter(context.Background(), tt.model)
+			if diags.HasError() {
+				t.Fatalf("newSecretFilter() diagnostics = %v", diags)
+			}
+			if got := filter.match(secret); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSecretFilterInvalidPatterns(t *testing.T) {
+	t.Parallel()
+
+	_, diags := newSecretFilter(context.Background(), secretFilterModel{
+		NameGlob:  types.StringValue("app-["),
+		NameRegex: types.StringValue("app-("),
+	})
+	if got := diags.ErrorsCount(); got != 2 {
+		t.Errorf("newSecretFilter() returned %d errors, want 2: %v", got, diags)
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = (*SecretListResource)(nil)
+var _ list.ListResourceWithConfigure = (*SecretListResource)(nil)
+
+func NewSecretListResource() list.ListResource {
+	return &SecretListResource{}
+}
+
+// SecretListResource defines the list resource implementation.
+// The results use the resource and identity schemas of SecretResource,
+// so that `terraform query -generate-config-out` generates the configuration to import the secrets.
+type SecretListResource struct {
+	client            Client
+	defaultKeyVaultID string
+	defaultTags       map[string]string
+}
+
+type SecretListResourceConfigModel struct {
+	secretFilterModel
+	KeyVaultID types.String `tfsdk:"key_vault_id"`
+	PageSize   types.Int32  `tfsdk:"page_size"`
+}
+
+func (r *SecretListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *SecretListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Key Vault Secrets in a Key Vault, so that existing secrets can be adopted with `terraform query -generate-config-out`. " +
+			"The generated configuration doesn't manage the values of the secrets until one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` is added.\n\n" +
+			"Secrets backing Key Vault Certificates are not listed since they can't be managed as secrets.\n\n" +
+			"~> This list resource requires the `Microsoft.KeyVault/vaults/secrets/readMetadata/action` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault to list Key Vault Secrets in. Defaults to `key_vault_id` of the provider.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "The prefix of the names of the Key Vault Secrets to list.",
+				Optional:            true,
+			},
+			"name_glob": schema.StringAttribute{
+				MarkdownDescription: "The glob pattern, such as `app-*-password`, that the names of the Key Vault Secrets to list must match.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "The regular expression that the names of the Key Vault Secrets to list must match.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "The tags that the Key Vault Secrets to list must have with the same values.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"page_size": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of Key Vault Secrets requested in a page, up to `%d`. "+
+					"The secrets are listed page by page, and listing stops once `limit` of the `list` block is reached.", maxListPageSize),
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, maxListPageSize),
+				},
+			},
+		},
+	}
+}
+
+func (r *SecretListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.defaultKeyVaultID = data.DefaultKeyVaultID
+	r.defaultTags = data.DefaultTags
+}
+
+func (r *SecretListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config SecretListResourceConfigModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	keyVaultID := config.KeyVaultID.ValueString()
+	if keyVaultID == "" {
+		if r.defaultKeyVaultID == "" {
+			diags.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		keyVaultID = r.defaultKeyVaultID
+	}
+
+	filter, filterDiags := newSecretFilter(ctx, config.secretFilterModel)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	options := &ListSecretPropertiesOptions{PageSize: config.PageSize.ValueInt32()}
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for secret, err := range r.client.ListSecretProperties(ctx, keyVaultID, options) {
+			if err != nil {
+				diags.AddError("Failed to List Secrets", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			if (secret.Managed != nil && *secret.Managed) || !filter.match(secret) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			name := secret.ID.Name()
+			result := req.NewListResult(ctx)
+			result.DisplayName = name
+
+			identity := newSecretResourceIdentity(types.StringValue(name), types.StringValue(keyVaultID))
+			result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+			if req.IncludeResource {
+				result.Diagnostics.Append(r.setListedResource(ctx, result.Resource, keyVaultID, secret)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setListedResource sets the attributes in the configuration generated for the secret.
+// The computed attributes are set on import, since the listed properties have no versions.
+func (r *SecretListResource) setListedResource(ctx context.Context, res *tfsdk.Resource, keyVaultID string, secret *azsecrets.SecretProperties) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var model SecretResourceModel
+	model.Tags, diags = types.MapValueFrom(ctx, types.StringType, secret.Tags)
+	if diags.HasError() {
+		return diags
+	}
+	// The checksum tag is regarded as an ordinary tag because track_value_checksum is false
+	diags.Append(splitDefaultTags(ctx, &model, nil, r.defaultTags)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	contentType := ""
+	if secret.ContentType != nil {
+		contentType = *secret.ContentType
+	}
+	notBeforeDate, expirationDate := timetypes.NewRFC3339Null(), timetypes.NewRFC3339Null()
+	if secret.Attributes != nil && secret.Attributes.NotBefore != nil {
+		notBeforeDate = timetypes.NewRFC3339TimeValue(secret.Attributes.NotBefore.UTC())
+	}
+	if secret.Attributes != nil && secret.Attributes.Expires != nil {
+		expirationDate = timetypes.NewRFC3339TimeValue(secret.Attributes.Expires.UTC())
+	}
+
+	diags.Append(res.SetAttribute(ctx, path.Root("name"), secret.ID.Name())...)
+	diags.Append(res.SetAttribute(ctx, path.Root("key_vault_id"), keyVaultID)...)
+	diags.Append(res.SetAttribute(ctx, path.Root("versionless_id"), string(*secret.ID))...)
+	diags.Append(res.SetAttribute(ctx, path.Root("content_type"), contentType)...)
+	diags.Append(res.SetAttribute(ctx, path.Root("not_before_date"), notBeforeDate)...)
+	diags.Append(res.SetAttribute(ctx, path.Root("expiration_date"), expirationDate)...)
+	diags.Append(res.SetAttribute(ctx, path.Root("tags"), model.Tags)...)
+	diags.Append(res.SetAttribute(ctx, path.Root("tags_all"), model.TagsAll)...)
+	diags.Append(setUnmanagedAttributes(ctx, res)...)
+
+	return diags
+}
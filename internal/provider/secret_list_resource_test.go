@@ -0,0 +1,67 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSecretListResource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: secretListResourceConfig(rn),
+			},
+			{
+				Query:  true,
+				Config: secretListResourceQueryConfig(rn),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("azurekv_secret.test", 1),
+				},
+			},
+		},
+	})
+}
+
+func secretListResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = "secret-value"
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func secretListResourceQueryConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`
+provider "azurekv" {}
+
+list "azurekv_secret" "test" {
+  provider = azurekv
+
+  config {
+    key_vault_id = %q
+  }
+}
+`, keyVaultIDInConfig(resourceSuffix))
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -22,6 +23,8 @@ const (
 var (
 	idRegex         = regexp.MustCompile(`\Ahttps://(` + keyVaultNamePattern + `)\.vault\.azure\.net/secrets/([^/]+)`)
 	keyVaultIDRegex = regexp.MustCompile(`\A/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.KeyVault/vaults/(` + keyVaultNamePattern + `)\z`)
+	vaultNameRegex  = regexp.MustCompile(`\A` + keyVaultNamePattern + `\z`)
+	vaultURIRegex   = regexp.MustCompile(`\Ahttps://(` + keyVaultNamePattern + `)\.vault\.azure\.net/?\z`)
 )
 
 type SecretModel interface {
@@ -50,11 +53,16 @@ func setSecretData(s SecretModel, id *azsecrets.ID, attrs *azsecrets.SecretAttri
 		s.SetContentType(types.StringValue(*contentType))
 	}
 
+	// Set null values explicitly so that the dates removed in Key Vault are also removed from the state
 	if attrs.NotBefore != nil {
 		s.SetNotBeforeDate(timetypes.NewRFC3339TimePointerValue(to.Ptr(attrs.NotBefore.UTC())))
+	} else {
+		s.SetNotBeforeDate(timetypes.NewRFC3339Null())
 	}
 	if attrs.Expires != nil {
 		s.SetExpirationDate(timetypes.NewRFC3339TimePointerValue(to.Ptr(attrs.Expires.UTC())))
+	} else {
+		s.SetExpirationDate(timetypes.NewRFC3339Null())
 	}
 
 	if tags != nil {
@@ -78,6 +86,51 @@ func extractVaultName(keyVaultID string) (string, error) {
 	return matches[1], nil
 }
 
+func extractVaultNameFromURI(vaultURI string) (string, error) {
+	matches := vaultURIRegex.FindStringSubmatch(vaultURI)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("invalid vault URI: %q doesn't match %q", vaultURI, vaultURIRegex)
+	}
+
+	return matches[1], nil
+}
+
+// checkNamePattern returns an error if the name doesn't match name_pattern of the provider.
+func checkNamePattern(namePattern *regexp.Regexp, name string) error {
+	if namePattern == nil || namePattern.MatchString(name) {
+		return nil
+	}
+	return fmt.Errorf("the name %q doesn't match name_pattern of the provider: %q", name, namePattern)
+}
+
+var errMissingKeyVault = errors.New("one of key_vault_id, vault_name, vault_uri, or vault_alias must be specified unless key_vault_id is set on the provider")
+
+// resolveVaultAlias returns the key vault ID to which the alias is mapped by vault_aliases of the provider.
+func resolveVaultAlias(vaultAliases map[string]string, alias string) (string, error) {
+	keyVaultID, ok := vaultAliases[alias]
+	if !ok {
+		return "", fmt.Errorf("the vault alias %q is not defined in vault_aliases of the provider", alias)
+	}
+	return keyVaultID, nil
+}
+
+// resolveKeyVaultID returns the ID of the key vault specified by either vaultName or vaultURI.
+func resolveKeyVaultID(ctx context.Context, client Client, vaultName, vaultURI string) (string, error) {
+	if vaultURI != "" {
+		var err error
+		vaultName, err = extractVaultNameFromURI(vaultURI)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if client.GetSubscriptionID() == "" {
+		return "", fmt.Errorf("subscription ID is required to resolve the key vault ID of %q", vaultName)
+	}
+
+	return client.GetKeyVaultID(ctx, vaultName, nil)
+}
+
 func extractVaultNameAndName(id string) (string, string, error) {
 	matches := idRegex.FindStringSubmatch(id)
 	if len(matches) == 0 {
@@ -86,3 +139,26 @@ func extractVaultNameAndName(id string) (string, string, error) {
 
 	return matches[1], matches[2], nil
 }
+
+// checkImportedSecretID returns an error if the ID of the secret fetched on import doesn't belong to the key vault,
+// or if the name differs only in case, so that import doesn't leave a state that fails or forces replacement on the next refresh.
+// The ID is parsed relative to the URL returned by vaultURL so that the secrets of an emulator can also be imported.
+func checkImportedSecretID(vaultURL func(vaultName string) string, keyVaultID, name string, id *azsecrets.ID) error {
+	vaultName, err := extractVaultName(keyVaultID)
+	if err != nil {
+		return err
+	}
+
+	// The host of Key Vault is case-insensitive, but the path isn't
+	prefix := strings.TrimSuffix(vaultURL(vaultName), "/") + "/secrets/"
+	if len(*id) < len(prefix) || !strings.EqualFold(string(*id)[:len(prefix)], prefix) {
+		return fmt.Errorf("the secret %q doesn't belong to the key vault %q whose URL is %q", string(*id), vaultName, vaultURL(vaultName))
+	}
+
+	idName, _, _ := strings.Cut(string(*id)[len(prefix):], "/")
+	if idName != name {
+		return fmt.Errorf("the secret name %q doesn't match the name %q in Key Vault; specify the name exactly as stored", name, idName)
+	}
+
+	return nil
+}
@@ -1,8 +1,15 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"regexp"
 	"strings"
 	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestExtractVaultName(t *testing.T) {
@@ -72,6 +79,178 @@ func TestExtractVaultName(t *testing.T) {
 	}
 }
 
+func TestExtractVaultNameFromURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		vaultURI string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "with trailing slash",
+			vaultURI: "https://vault-name.vault.azure.net/",
+			want:     "vault-name",
+		},
+		{
+			name:     "without trailing slash",
+			vaultURI: "https://vault-name.vault.azure.net",
+			want:     "vault-name",
+		},
+		{
+			name:     "with path",
+			vaultURI: "https://vault-name.vault.azure.net/secrets/secret",
+			wantErr:  true,
+		},
+		{
+			name:     "too short",
+			vaultURI: "https://ab.vault.azure.net/",
+			wantErr:  true,
+		},
+		{
+			name:     "with invalid characters",
+			vaultURI: "https://example.com?a=.vault.azure.net/",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := extractVaultNameFromURI(tt.vaultURI)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("extractVaultNameFromURI() error = nil, want an error")
+				}
+				if got != "" {
+					t.Errorf("extractVaultNameFromURI() = %q, want empty", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractVaultNameFromURI() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("extractVaultNameFromURI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveVaultAlias(t *testing.T) {
+	t.Parallel()
+
+	keyVaultID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-name/providers/Microsoft.KeyVault/vaults/vault-name"
+	vaultAliases := map[string]string{"platform": keyVaultID}
+
+	got, err := resolveVaultAlias(vaultAliases, "platform")
+	if err != nil {
+		t.Fatalf("resolveVaultAlias() error = %v", err)
+	}
+	if got != keyVaultID {
+		t.Errorf("resolveVaultAlias() = %q, want %q", got, keyVaultID)
+	}
+
+	if _, err := resolveVaultAlias(vaultAliases, "unknown"); err == nil {
+		t.Error("resolveVaultAlias() error = nil, want an error for an undefined alias")
+	}
+}
+
+func TestCheckNamePattern(t *testing.T) {
+	t.Parallel()
+
+	namePattern := regexp.MustCompile(`^[a-z0-9]+--(dev|prd)--[a-z0-9-]+$`)
+
+	tests := []struct {
+		name        string
+		namePattern *regexp.Regexp
+		secretName  string
+		wantErr     bool
+	}{
+		{
+			name:        "matching name",
+			namePattern: namePattern,
+			secretName:  "app--prd--db-password",
+		},
+		{
+			name:        "not matching name",
+			namePattern: namePattern,
+			secretName:  "db-password",
+			wantErr:     true,
+		},
+		{
+			name:       "no pattern",
+			secretName: "db-password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := checkNamePattern(tt.namePattern, tt.secretName); (err != nil) != tt.wantErr {
+				t.Errorf("checkNamePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecretDataSourceModelResolveKeyVaultID(t *testing.T) {
+	t.Parallel()
+
+	const (
+		defaultKeyVaultID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-name/providers/Microsoft.KeyVault/vaults/default-vault"
+		aliasKeyVaultID   = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-name/providers/Microsoft.KeyVault/vaults/alias-vault"
+	)
+	vaultAliases := map[string]string{"platform": aliasKeyVaultID}
+
+	tests := []struct {
+		name              string
+		model             SecretDataSourceModel
+		defaultKeyVaultID string
+		want              string
+		wantErr           error
+	}{
+		{
+			name:              "default",
+			defaultKeyVaultID: defaultKeyVaultID,
+			want:              defaultKeyVaultID,
+		},
+		{
+			name:    "no default",
+			wantErr: errMissingKeyVault,
+		},
+		{
+			name:              "vault_alias",
+			model:             SecretDataSourceModel{VaultAlias: types.StringValue("platform")},
+			defaultKeyVaultID: defaultKeyVaultID,
+			want:              aliasKeyVaultID,
+		},
+		{
+			name:              "vault_name",
+			model:             SecretDataSourceModel{VaultName: types.StringValue("vault-name")},
+			defaultKeyVaultID: defaultKeyVaultID,
+			want:              "/subscriptions/" + mockSubscriptionID + "/resourceGroups/mock/providers/Microsoft.KeyVault/vaults/vault-name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.model.resolveKeyVaultID(context.Background(), newMockClient(""), vaultAliases, tt.defaultKeyVaultID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveKeyVaultID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveKeyVaultID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExtractVaultNameAndName(t *testing.T) {
 	t.Parallel()
 
@@ -154,3 +333,91 @@ func TestExtractVaultNameAndName(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckImportedSecretID(t *testing.T) {
+	t.Parallel()
+
+	keyVaultID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name"
+	emulator := &client{options: ClientOptions{VaultEndpoint: "https://localhost:8443/"}}
+
+	tests := []struct {
+		name       string
+		vaultURL   func(string) string
+		keyVaultID string
+		secretName string
+		id         string
+		wantErr    bool
+	}{
+		{
+			name:       "consistent",
+			keyVaultID: keyVaultID,
+			secretName: "secret",
+			id:         "https://vault-name.vault.azure.net/secrets/secret/version",
+		},
+		{
+			name:       "vault name in different case",
+			keyVaultID: keyVaultID,
+			secretName: "secret",
+			id:         "https://VAULT-NAME.vault.azure.net/secrets/secret/version",
+		},
+		{
+			name:       "different vault",
+			keyVaultID: keyVaultID,
+			secretName: "secret",
+			id:         "https://other-vault.vault.azure.net/secrets/secret/version",
+			wantErr:    true,
+		},
+		{
+			name:       "name in different case",
+			keyVaultID: keyVaultID,
+			secretName: "Secret",
+			id:         "https://vault-name.vault.azure.net/secrets/secret/version",
+			wantErr:    true,
+		},
+		{
+			name:       "custom vault endpoint",
+			vaultURL:   emulator.VaultURL,
+			keyVaultID: keyVaultID,
+			secretName: "secret",
+			id:         "https://localhost:8443/secrets/secret/version",
+		},
+		{
+			name:       "custom vault endpoint with name in different case",
+			vaultURL:   emulator.VaultURL,
+			keyVaultID: keyVaultID,
+			secretName: "Secret",
+			id:         "https://localhost:8443/secrets/secret/version",
+			wantErr:    true,
+		},
+		{
+			name:       "Key Vault secret with custom vault endpoint",
+			vaultURL:   emulator.VaultURL,
+			keyVaultID: keyVaultID,
+			secretName: "secret",
+			id:         "https://vault-name.vault.azure.net/secrets/secret/version",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid key vault ID",
+			keyVaultID: "vault-name",
+			secretName: "secret",
+			id:         "https://vault-name.vault.azure.net/secrets/secret/version",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vaultURL := tt.vaultURL
+			if vaultURL == nil {
+				vaultURL = defaultVaultURL
+			}
+			err := checkImportedSecretID(vaultURL, tt.keyVaultID, tt.secretName, to.Ptr(azsecrets.ID(tt.id)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkImportedSecretID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,12 +2,22 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -15,11 +25,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -28,12 +40,23 @@ const (
 	LogKeyResourceID = "resource_id"
 )
 
+// The values of delete_behavior
+const (
+	deleteBehaviorDelete  = "delete"
+	deleteBehaviorDisable = "disable"
+	deleteBehaviorAbandon = "abandon"
+)
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = (*SecretResource)(nil)
 var _ resource.ResourceWithConfigure = (*SecretResource)(nil)
 var _ resource.ResourceWithModifyPlan = (*SecretResource)(nil)
 var _ resource.ResourceWithImportState = (*SecretResource)(nil)
 var _ resource.ResourceWithIdentity = (*SecretResource)(nil)
+var _ resource.ResourceWithConfigValidators = (*SecretResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*SecretResource)(nil)
+var _ resource.ResourceWithMoveState = (*SecretResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*SecretResource)(nil)
 
 func NewSecretResource() resource.Resource {
 	return &SecretResource{}
@@ -41,20 +64,75 @@ func NewSecretResource() resource.Resource {
 
 // SecretResource defines the resource implementation.
 type SecretResource struct {
-	client Client
+	client                   Client
+	purgeSoftDeleteOnDestroy bool
+	expirationWarningDays    int32
+	defaultTags              map[string]string
+	requiredTags             []string
+	namePattern              *regexp.Regexp
+	rbacPropagationTimeout   time.Duration
+	purgeWaitTimeout         time.Duration
+	recoverSoftDeleted       bool
+	vaultAliases             map[string]string
+	defaultKeyVaultID        string
+	mockMode                 bool
 }
 
 type SecretResourceModel struct {
 	SecretDataSourceModel
-	ValueWO        types.String `tfsdk:"value_wo"`
-	ValueWOVersion types.Int32  `tfsdk:"value_wo_version"`
+	Value                    types.String         `tfsdk:"value"`
+	ValueWO                  types.String         `tfsdk:"value_wo"`
+	ValueJSONWO              types.Dynamic        `tfsdk:"value_json_wo"`
+	ValueSourceEnv           types.String         `tfsdk:"value_source_env"`
+	ValueSourceCommand       types.List           `tfsdk:"value_source_command"`
+	ValueWOVersion           types.Dynamic        `tfsdk:"value_wo_version"`
+	ValueWOTrigger           types.String         `tfsdk:"value_wo_trigger"`
+	Triggers                 types.Map            `tfsdk:"triggers"`
+	OverwriteExisting        types.Bool           `tfsdk:"overwrite_existing"`
+	PurgeSoftDeleteOnDestroy types.Bool           `tfsdk:"purge_soft_delete_on_destroy"`
+	DeleteBehavior           types.String         `tfsdk:"delete_behavior"`
+	ExpiresIn                timetypes.GoDuration `tfsdk:"expires_in"`
+	RollingExpirationDays    types.Int32          `tfsdk:"rolling_expiration_days"`
+	MaxVersionsToKeep        types.Int32          `tfsdk:"max_versions_to_keep"`
+	PrunedVersionTags        types.Map            `tfsdk:"pruned_version_tags"`
+	TrackValueChecksum       types.Bool           `tfsdk:"track_value_checksum"`
+	DetectExternalChanges    types.Bool           `tfsdk:"detect_external_changes"`
+	InferContentType         types.Bool           `tfsdk:"infer_content_type"`
+	TagsAll                  types.Map            `tfsdk:"tags_all"`
+	PinnedVersion            types.String         `tfsdk:"pinned_version"`
+	ValueLength              types.Int64          `tfsdk:"value_length"`
+	ValueEntropyClass        types.String         `tfsdk:"value_entropy_class"`
+	TrackLatestVersion       types.Bool           `tfsdk:"track_latest_version"`
+	ValueChecksum            types.String         `tfsdk:"value_checksum"`
+	PreviousVersion          types.String         `tfsdk:"previous_version"`
+	PreviousID               types.String         `tfsdk:"previous_id"`
+	IncludeVersions          types.Bool           `tfsdk:"include_versions"`
+	Versions                 types.List           `tfsdk:"versions"`
+	StableID                 types.Bool           `tfsdk:"stable_id"`
+	VersionedID              types.String         `tfsdk:"versioned_id"`
 }
 
 var _ SecretModel = (*SecretResourceModel)(nil)
 
 type SecretResourceIdentityModel struct {
-	Name       types.String `tfsdk:"name"`
-	KeyVaultID types.String `tfsdk:"key_vault_id"`
+	Name           types.String `tfsdk:"name"`
+	KeyVaultID     types.String `tfsdk:"key_vault_id"`
+	SubscriptionID types.String `tfsdk:"subscription_id"`
+	Version        types.String `tfsdk:"version"`
+}
+
+func newSecretResourceIdentity(name, keyVaultID types.String) SecretResourceIdentityModel {
+	identity := SecretResourceIdentityModel{
+		Name:           name,
+		KeyVaultID:     keyVaultID,
+		SubscriptionID: types.StringNull(),
+		// The version is only used on import, since the resource manages the secret rather than the version
+		Version: types.StringNull(),
+	}
+	if id, err := arm.ParseResourceID(keyVaultID.ValueString()); err == nil {
+		identity.SubscriptionID = types.StringValue(id.SubscriptionID)
+	}
+	return identity
 }
 
 func (r *SecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,7 +141,9 @@ func (r *SecretResource) Metadata(_ context.Context, req resource.MetadataReques
 
 func (r *SecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Key Vault secret. This resource provides the same interface as [`azurerm_key_vault_secret`](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_secret), but does not require the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission.",
+		Version: 1,
+		MarkdownDescription: "Manages a Key Vault secret. This resource provides the same interface as [`azurerm_key_vault_secret`](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_secret), but does not require the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission.\n\n" +
+			"Secrets managed by [`azurerm_key_vault_secret`](https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/key_vault_secret) or [`azapi_resource`](https://registry.terraform.io/providers/Azure/azapi/latest/docs/resources/azapi_resource) with the type `Microsoft.KeyVault/vaults/secrets` can be moved to this resource with a [`moved` block](https://developer.hashicorp.com/terraform/language/moved) in Terraform 1.8 and later.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -74,17 +154,56 @@ func (r *SecretResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"key_vault_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the Key Vault where the Secret should be created. Changing this forces a new resource to be created.",
-				Required:            true,
+				MarkdownDescription: "The ID of the Key Vault where the Secret should be created. Changing this forces a new resource to be created. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
+					// The ID resolved from the other attributes or the provider is planned in ModifyPlan
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 				Validators: []validator.String{
 					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
 				},
 			},
+			"vault_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the Key Vault where the Secret should be created. " +
+					"The ID of the Key Vault is resolved with the `Microsoft.KeyVault/vaults/read` permission in the subscription of the provider. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vaultNameRegex, ""),
+				},
+			},
+			"vault_uri": schema.StringAttribute{
+				MarkdownDescription: "The URI of the Key Vault where the Secret should be created, such as `https://example.vault.azure.net/`. " +
+					"The ID of the Key Vault is resolved with the `Microsoft.KeyVault/vaults/read` permission in the subscription of the provider. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(vaultURIRegex, ""),
+				},
+			},
+			"vault_alias": schema.StringAttribute{
+				MarkdownDescription: "The name of the Key Vault in `vault_aliases` of the provider where the Secret should be created. " +
+					"Changing the Key Vault to which the alias is mapped forces a new resource to be created. " +
+					"At most one of `key_vault_id`, `vault_name`, `vault_uri`, or `vault_alias` can be specified. If none of them is specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+			},
 			"id": schema.StringAttribute{
-				MarkdownDescription: "The Key Vault Secret ID.",
+				MarkdownDescription: "The Key Vault Secret ID. This is the same as `versionless_id` if `stable_id` is `true`, or `versioned_id` otherwise.",
+				Computed:            true,
+			},
+			"stable_id": schema.BoolAttribute{
+				MarkdownDescription: "Whether to use the versionless ID as `id`, so that references to `id` don't change on every new version. " +
+					"The ID of the current version is available as `versioned_id` either way. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"versioned_id": schema.StringAttribute{
+				MarkdownDescription: "The Key Vault Secret ID of the current version.",
 				Computed:            true,
 			},
 			"versionless_id": schema.StringAttribute{
@@ -94,15 +213,182 @@ func (r *SecretResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Specifies the value of the Key Vault Secret. Unlike `value_wo`, the value is stored in the Terraform state, and any change of it creates a new version of the Key Vault Secret. " +
+					"Use this only with Terraform versions that don't support write-only attributes (earlier than 1.11); otherwise, use `value_wo`. " +
+					"At most one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` can be specified. If none of them is specified, the value is not managed, which is only allowed for imported resources.",
+				Optional:  true,
+				Sensitive: true,
+			},
 			"value_wo": schema.StringAttribute{
-				MarkdownDescription: "Specifies the value of the Key Vault Secret. Changing this will create a new version of the Key Vault Secret.",
-				Required:            true,
-				Sensitive:           true,
-				WriteOnly:           true,
+				MarkdownDescription: "Specifies the value of the Key Vault Secret. Changing this will create a new version of the Key Vault Secret. " +
+					"At most one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` can be specified. If none of them is specified, the value is not managed, which is only allowed for imported resources.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
 			},
-			"value_wo_version": schema.Int32Attribute{
-				MarkdownDescription: "An integer value used to trigger an update for `value_wo`. This property should be incremented when updating `value_wo`.",
-				Required:            true,
+			"value_json_wo": schema.DynamicAttribute{
+				MarkdownDescription: "Specifies the value of the Key Vault Secret as an object or a map, which is serialized to JSON with sorted keys. " +
+					"Unlike `jsonencode`, the same value always results in the same JSON regardless of how it is built. " +
+					"`content_type` defaults to `application/json`. As with `value_wo`, the value is updated only when `value_wo_version`, `value_wo_trigger`, or `triggers` changes. " +
+					"At most one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` can be specified. If none of them is specified, the value is not managed, which is only allowed for imported resources.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"value_source_env": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the environment variable to read the value of the Key Vault Secret from. " +
+					"The environment variable is read on the machine running `terraform apply`, so the value passes through neither Terraform variables nor plan files. " +
+					"As with `value_wo`, the value is updated only when `value_wo_version`, `value_wo_trigger`, or `triggers` changes. " +
+					"At most one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` can be specified. If none of them is specified, the value is not managed, which is only allowed for imported resources.",
+				Optional: true,
+			},
+			"value_source_command": schema.ListAttribute{
+				MarkdownDescription: "Specifies the command and its arguments to run to get the value of the Key Vault Secret, e.g. `[\"op\", \"read\", \"op://vault/item/password\"]`. " +
+					"The command is run on the machine running `terraform apply` and its standard output without trailing newlines is used as the value. " +
+					"As with `value_wo`, the value is updated only when `value_wo_version`, `value_wo_trigger`, or `triggers` changes. " +
+					"At most one of `value`, `value_wo`, `value_json_wo`, `value_source_env`, or `value_source_command` can be specified. If none of them is specified, the value is not managed, which is only allowed for imported resources.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"value_wo_version": schema.DynamicAttribute{
+				MarkdownDescription: "An integer or a string used to trigger an update for `value_wo`, such as a timestamp or an upstream revision. This property should be changed when updating `value_wo`. " +
+					"Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified unless `value` is specified or the value is not managed.",
+				Optional: true,
+			},
+			"value_wo_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary string used to trigger an update for `value_wo`, such as a timestamp, a file hash, or an upstream version. " +
+					"Any change of this property updates `value_wo`. Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified unless `value` is specified or the value is not managed.",
+				Optional: true,
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "A map of arbitrary strings used to trigger an update for `value_wo`, such as an application version and a certificate thumbprint. " +
+					"Any change of the entries updates `value_wo`. Exactly one of `value_wo_version`, `value_wo_trigger`, or `triggers` must be specified unless `value` is specified or the value is not managed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"overwrite_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to add a new version to the Key Vault Secret if a secret with the same name already exists when creating the resource. " +
+					"If `false`, the creation fails so that multiple configurations don't manage the same secret. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"purge_soft_delete_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to purge the Key Vault Secret after deleting it on destroy, so that a secret with the same name can be created immediately. " +
+					"This is used only if `delete_behavior` is `delete`. Defaults to the `purge_soft_delete_on_destroy` of the provider.",
+				Optional: true,
+			},
+			"delete_behavior": schema.StringAttribute{
+				MarkdownDescription: "Specifies what to do with the Key Vault Secret on destroy: `delete` deletes the secret, " +
+					"`disable` disables all the versions, and `abandon` only removes the secret from the state. " +
+					"`disable` and `abandon` are useful when applications sharing the Key Vault must keep reading the secret, such as during migration. Defaults to `delete`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(deleteBehaviorDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteBehaviorDelete, deleteBehaviorDisable, deleteBehaviorAbandon),
+				},
+			},
+			"max_versions_to_keep": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the number of the newest versions of the Key Vault Secret to keep enabled. " +
+					"Older versions are disabled after a new version is created, so that the Key Vault doesn't accumulate usable stale versions. " +
+					"Defaults to keeping all the versions enabled.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"pruned_version_tags": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags to add to the versions disabled by `max_versions_to_keep`. Requires `max_versions_to_keep`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("max_versions_to_keep")),
+				},
+			},
+			"track_value_checksum": schema.BoolAttribute{
+				MarkdownDescription: "Whether to store the SHA-256 checksum of the value in the `" + valueChecksumTagName + "` tag of each version written by this resource. " +
+					"If the checksum of the current version differs from that of the value written by this resource, " +
+					"the value is regarded as changed outside of Terraform and is written again. Defaults to `false`.\n\n" +
+					"~> The checksum is readable by anyone who can read the secret properties, so enable this only for secrets with enough entropy, such as generated passwords and keys.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"value_checksum": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 checksum of the value stored in the tag of the current version if `track_value_checksum` is `true`.",
+				Computed:            true,
+			},
+			"detect_external_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether to regard a version of the Key Vault Secret newer than the one written by this resource as a change outside of Terraform. " +
+					"If `true`, a warning is shown on refresh and the value is written again as a new version, " +
+					"instead of adopting the newer version silently. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"pinned_version": schema.StringAttribute{
+				MarkdownDescription: "Specifies the version of the Key Vault Secret to manage instead of the latest version. " +
+					"This is set when the resource is imported with a versioned ID or `version` in the identity, and can only be used for imported resources " +
+					"because the value of the pinned version can't be updated. Removing this manages the latest version.",
+				Optional: true,
+			},
+			"track_latest_version": schema.BoolAttribute{
+				MarkdownDescription: "Whether to update `version` and `id` to the latest version of the Key Vault Secret on refresh. " +
+					"If `false`, refresh only verifies that the version in the state still exists, so that consumers can reference the exact version written by this resource. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"include_versions": schema.BoolAttribute{
+				MarkdownDescription: "Whether to populate `versions` with all the versions of the Key Vault Secret. " +
+					"This requires listing the versions on every refresh, so enable this only when the rotation history is needed. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "The versions of the Key Vault Secret from the newest to the oldest if `include_versions` is `true`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The version of the Key Vault Secret.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The Key Vault Secret ID of the version.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the version is enabled.",
+							Computed:            true,
+						},
+						"created_date": schema.StringAttribute{
+							MarkdownDescription: "The UTC datetime when the version was created.",
+							Computed:            true,
+							CustomType:          timetypes.RFC3339Type{},
+						},
+						"expiration_date": schema.StringAttribute{
+							MarkdownDescription: "The expiration UTC datetime of the version.",
+							Computed:            true,
+							CustomType:          timetypes.RFC3339Type{},
+						},
+					},
+				},
+			},
+			"value_length": schema.Int64Attribute{
+				MarkdownDescription: "The number of characters of the value written by this resource. This is null for imported resources until a new version is created.",
+				Computed:            true,
+			},
+			"value_entropy_class": schema.StringAttribute{
+				MarkdownDescription: "The rough strength of the value written by this resource, estimated from its length and character classes: " +
+					"`low` (less than 64 bits), `medium` (less than 128 bits), or `high`. This is null for imported resources until a new version is created.",
+				Computed: true,
 			},
 			"content_type": schema.StringAttribute{
 				MarkdownDescription: "Specifies the content type for the Key Vault Secret.",
@@ -111,20 +397,55 @@ func (r *SecretResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				// The default value of azurerm provider
 				Default: stringdefault.StaticString(""),
 			},
+			"infer_content_type": schema.BoolAttribute{
+				MarkdownDescription: "Whether to set `content_type` inferred from the value when a new version is created: " +
+					"`application/json` for JSON, `application/x-pem-file` for PEM, and `application/x-pkcs12` for base64-encoded PKCS#12. " +
+					"`content_type` is set to an empty string if the value doesn't look like any of them. Can't be `true` if `content_type` is specified. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"not_before_date": schema.StringAttribute{
 				MarkdownDescription: "Key not usable before the provided UTC datetime (Y-m-d'T'H:M:S'Z').",
 				Optional:            true,
 				CustomType:          timetypes.RFC3339Type{},
 			},
 			"expiration_date": schema.StringAttribute{
-				MarkdownDescription: "Expiration UTC datetime (Y-m-d'T'H:M:S'Z').",
+				MarkdownDescription: "Expiration UTC datetime (Y-m-d'T'H:M:S'Z'). Conflicts with `expires_in`.",
 				Optional:            true,
+				Computed:            true,
 				CustomType:          timetypes.RFC3339Type{},
 			},
+			"expires_in": schema.StringAttribute{
+				MarkdownDescription: "Specifies the duration until the Key Vault Secret expires, such as `2160h`. " +
+					"`expiration_date` is set to this duration after the time when a new version of the Key Vault Secret is created, " +
+					"so every new version gets a fresh expiration date. Conflicts with `expiration_date`.",
+				Optional:   true,
+				CustomType: timetypes.GoDurationType{},
+			},
+			"rolling_expiration_days": schema.Int32Attribute{
+				MarkdownDescription: "Specifies the number of days before `expiration_date` within which `expiration_date` is extended to `expires_in` after the time of apply, " +
+					"even if no new version is created. This is useful when the Key Vault Secret is rotated out-of-band but must have an expiration date. " +
+					"Requires `expires_in`.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+					int32validator.AlsoRequires(path.MatchRoot("expires_in")),
+				},
+			},
 			"version": schema.StringAttribute{
 				MarkdownDescription: "The current version of the Key Vault Secret.",
 				Computed:            true,
 			},
+			"previous_version": schema.StringAttribute{
+				MarkdownDescription: "The version of the Key Vault Secret that was current before this resource created the current version. " +
+					"This is useful to roll back or to read both versions during a rotation. This is null until the value is rotated by this resource.",
+				Computed: true,
+			},
+			"previous_id": schema.StringAttribute{
+				MarkdownDescription: "The Key Vault Secret ID of `previous_version`.",
+				Computed:            true,
+			},
 			"resource_id": schema.StringAttribute{
 				MarkdownDescription: "The (Versioned) ID for this Key Vault Secret. This property points to a specific version of a Key Vault Secret, as such using this won't auto-rotate values if used in other Azure Services.",
 				Computed:            true,
@@ -137,12 +458,17 @@ func (r *SecretResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"tags": schema.MapAttribute{
-				MarkdownDescription: "A mapping of tags to assign to the resource.",
+				MarkdownDescription: "A mapping of tags to assign to the resource. These tags override the `default_tags` of the provider with the same keys. Up to 15 tags including the default tags can be assigned, and each key and value can have up to 512 and 256 characters respectively.",
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
 				Default:             mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
 			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "A mapping of tags assigned to the resource, including the `default_tags` of the provider.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -158,42 +484,162 @@ func (r *SecretResource) IdentitySchema(_ context.Context, _ resource.IdentitySc
 				Description:       "The ID of the Key Vault where the Secret is managed.",
 				RequiredForImport: true,
 			},
+			"subscription_id": identityschema.StringAttribute{
+				Description:       "The ID of the subscription of the Key Vault. If specified on import, it must match the subscription in `key_vault_id`.",
+				OptionalForImport: true,
+			},
+			"version": identityschema.StringAttribute{
+				Description:       "The version of the Key Vault Secret to pin on import, which is the same as importing with the versioned ID. This is always null after import.",
+				OptionalForImport: true,
+			},
 		},
 	}
 }
 
+func (r *SecretResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("key_vault_id"),
+			path.MatchRoot("vault_name"),
+			path.MatchRoot("vault_uri"),
+			path.MatchRoot("vault_alias"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("value"),
+			path.MatchRoot("value_wo"),
+			path.MatchRoot("value_json_wo"),
+			path.MatchRoot("value_source_env"),
+			path.MatchRoot("value_source_command"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("value_wo_version"),
+			path.MatchRoot("value_wo_trigger"),
+			path.MatchRoot("triggers"),
+		),
+		resourcevalidator.Conflicting(
+			path.MatchRoot("expiration_date"),
+			path.MatchRoot("expires_in"),
+		),
+	}
+}
+
+func (r *SecretResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SecretResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateValueWOVersion(ctx, config.ValueWOVersion)...)
+
+	triggerSpecified := config.valueWOTriggers().specified()
+	if !config.Value.IsNull() {
+		if triggerSpecified {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("value"),
+				"Invalid Attribute Combination",
+				"value_wo_version, value_wo_trigger, and triggers can't be specified with value because any change of value creates a new version. "+
+					"Remove them, or use value_wo instead of value to keep the value out of the Terraform state.",
+			)
+		}
+		if !config.ValueWO.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("value"),
+				"Invalid Attribute Combination",
+				"value and value_wo can't be specified together. Use value_wo with Terraform 1.11 or later, which supports write-only attributes, "+
+					"or value with earlier versions, which stores the value in the Terraform state.",
+			)
+		}
+		return
+	}
+
+	if config.InferContentType.ValueBool() && !config.ContentType.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("infer_content_type"),
+			"Invalid Attribute Combination",
+			"infer_content_type can't be true if content_type is specified.",
+		)
+	}
+
+	// The value is not managed, e.g. in the configuration generated on import
+	if !config.hasValueSource() {
+		return
+	}
+
+	if !triggerSpecified {
+		resp.Diagnostics.AddError(
+			"Missing Attribute Configuration",
+			"Exactly one of value_wo_version, value_wo_trigger, or triggers must be specified to update the value. "+
+				"If your Terraform version doesn't support write-only attributes (earlier than 1.11), use value instead, whose changes are detected without them.",
+		)
+	}
+}
+
 func (r *SecretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.Client
+	r.purgeSoftDeleteOnDestroy = data.PurgeSoftDeleteOnDestroy
+	r.expirationWarningDays = data.ExpirationWarningDays
+	r.defaultTags = data.DefaultTags
+	r.requiredTags = data.RequiredTags
+	r.namePattern = data.NamePattern
+	r.rbacPropagationTimeout = data.RBACPropagationTimeout
+	r.purgeWaitTimeout = data.PurgeWaitTimeout
+	r.recoverSoftDeleted = data.RecoverSoftDeletedSecrets
+	r.vaultAliases = data.VaultAliases
+	r.defaultKeyVaultID = data.DefaultKeyVaultID
+	r.mockMode = data.MockMode
 }
 
 func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var model SecretResourceModel
-	var secretValue string
+	// Fail up front because the checks before writing would fail on the fake secrets of the mock client
+	if r.mockMode {
+		resp.Diagnostics.AddError("Mock Mode Enabled", errMockMode.Error())
+		return
+	}
+
+	var model, config SecretResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_wo"), &secretValue)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, LogKeyResourceID, model.ID.ValueString())
 
+	// The key vault ID is unknown on plan if vault_name, vault_uri, or vault_alias is unknown
+	if model.KeyVaultID.IsUnknown() {
+		keyVaultID, err := model.resolveKeyVaultID(ctx, r.client, r.vaultAliases, r.defaultKeyVaultID)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Get KeyVaults", err.Error()+azureRequestDetails(err))
+			return
+		}
+		model.KeyVaultID = types.StringValue(keyVaultID)
+	}
+
+	secretValue, diags := secretValueFromConfig(ctx, config)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(resolveExpirationDate(&model)...)
+
 	tags, diags := toMap(model.Tags)
 	resp.Diagnostics.Append(diags...)
+	tags = mergeDefaultTags(r.defaultTags, tags)
 
 	attrs, diags := buildSecretAttributes(model)
 	resp.Diagnostics.Append(diags...)
@@ -202,32 +648,89 @@ func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	setResp, err := r.client.SetSecret(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), azsecrets.SetSecretParameters{
-		Value:            to.Ptr(secretValue),
-		ContentType:      model.ContentType.ValueStringPointer(),
-		SecretAttributes: attrs,
-		Tags:             tags,
-	}, nil)
+	if model.TrackValueChecksum.ValueBool() {
+		tags[valueChecksumTagName] = to.Ptr(valueChecksum(secretValue))
+	}
+
+	if model.ContentType.IsUnknown() {
+		model.ContentType = types.StringValue(inferContentType(secretValue))
+	}
+
+	setValueStats(&model, secretValue)
+
+	model.PreviousVersion = types.StringNull()
+	model.PreviousID = types.StringNull()
+
+	if !model.OverwriteExisting.ValueBool() {
+		var existing *azsecrets.SecretProperties
+		err := retryOnForbiddenByRBAC(ctx, r.rbacPropagationTimeout, defaultPollInterval, func() (err error) {
+			existing, err = r.client.GetSecretProperties(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), "", nil)
+			return err
+		})
+		if err == nil {
+			resp.Diagnostics.AddError(
+				"Secret Already Exists",
+				fmt.Sprintf("The secret %q already exists. To manage it with Terraform, import it, or set overwrite_existing to true to add a new version to it.", *existing.ID),
+			)
+			return
+		}
+		if !isNotFoundError(err) {
+			resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
+	}
+
+	var setResp azsecrets.SetSecretResponse
+	err := retryOnForbiddenByRBAC(ctx, r.rbacPropagationTimeout, defaultPollInterval, func() (err error) {
+		parameters := azsecrets.SetSecretParameters{
+			Value:            to.Ptr(secretValue),
+			ContentType:      model.ContentType.ValueStringPointer(),
+			SecretAttributes: attrs,
+			Tags:             tags,
+		}
+		return retryWhileBeingDeleted(ctx, r.purgeWaitTimeout, defaultPollInterval, func() (err error) {
+			if r.recoverSoftDeleted {
+				setResp, err = setSecretRecoveringDeleted(ctx, r.client, model.KeyVaultID.ValueString(), model.Name.ValueString(), parameters, nil)
+			} else {
+				setResp, err = r.client.SetSecret(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), parameters, nil)
+			}
+			return err
+		})
+	})
 	if err != nil {
-		// TODO: Handle ObjectIsDeletedButRecoverable
 		resp.Diagnostics.AddError(
 			"Failed to Set Secret",
-			"An unexpected error occurred while setting a secret: "+err.Error(),
+			"An unexpected error occurred while setting a secret: "+err.Error()+azureErrorHint(err, dataActionSetSecret),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(setSecretData(&model, setResp.ID, setResp.Attributes, setResp.ContentType, setResp.Tags)...)
+	resp.Diagnostics.Append(r.setResourceData(ctx, &model, setResp.ID, setResp.Attributes, setResp.ContentType, setResp.Tags)...)
+	resp.Diagnostics.Append(setWrittenSecret(ctx, resp.Private, newWrittenSecret(model, secretValue))...)
+	resp.Diagnostics.Append(r.publishEvent(ctx, eventTypeSecretNewVersionCreated, model.KeyVaultID.ValueString(), model.Name.ValueString(), model.Version.ValueString())...)
+
+	resp.Diagnostics.Append(r.pruneVersions(ctx, model)...)
+	resp.Diagnostics.Append(r.setVersions(ctx, &model)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 
-	identity := SecretResourceIdentityModel{
-		Name:       model.Name,
-		KeyVaultID: model.KeyVaultID,
-	}
+	identity := newSecretResourceIdentity(model.Name, model.KeyVaultID)
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 }
 
+// publishEvent publishes the event of the secret to the Event Grid topic.
+// The secret has already been written or deleted, so a failure is only a warning.
+func (r *SecretResource) publishEvent(ctx context.Context, eventType, keyVaultID, name, version string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err := r.client.PublishEvent(ctx, eventType, keyVaultID, name, version); err != nil {
+		diags.AddWarning(
+			"Failed to Publish Event",
+			fmt.Sprintf("The event %s of the secret %q was not published to the Event Grid topic: %s", eventType, name, err),
+		)
+	}
+	return diags
+}
+
 func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var model SecretResourceModel
 
@@ -236,44 +739,72 @@ func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// Keep the prior state so that the fake values of the mock client don't appear as changes on plan
+	if r.mockMode {
+		identity := newSecretResourceIdentity(model.Name, model.KeyVaultID)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+		return
+	}
+
 	ctx = tflog.SetField(ctx, LogKeyResourceID, model.ID.ValueString())
 
-	secretProperties, err := r.client.GetSecretProperties(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), "", nil)
+	version := model.PinnedVersion.ValueString()
+	if version == "" && !model.TrackLatestVersion.IsNull() && !model.TrackLatestVersion.ValueBool() {
+		version = model.Version.ValueString()
+	}
+
+	secretProperties, err := r.client.GetSecretProperties(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString(), version, nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error())
+		resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error()+azureErrorHint(err, dataActionReadMetadata))
 		return
 	}
 
-	resp.Diagnostics.Append(setSecretData(&model, secretProperties.ID, secretProperties.Attributes, secretProperties.ContentType, secretProperties.Tags)...)
+	resp.Diagnostics.Append(r.setResourceData(ctx, &model, secretProperties.ID, secretProperties.Attributes, secretProperties.ContentType, secretProperties.Tags)...)
+
+	written, diags := getWrittenSecret(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if versionChangedExternally(model, model, written.version) {
+		resp.Diagnostics.AddWarning(
+			"Secret Changed Outside of Terraform",
+			fmt.Sprintf("The current version %q of the secret %q differs from the version %q written by Terraform. The value will be written again as a new version on the next apply.", model.Version.ValueString(), model.Name.ValueString(), written.version),
+		)
+	}
+
+	resp.Diagnostics.Append(r.setVersions(ctx, &model)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 
-	identity := SecretResourceIdentityModel{
-		Name:       model.Name,
-		KeyVaultID: model.KeyVaultID,
-	}
+	identity := newSecretResourceIdentity(model.Name, model.KeyVaultID)
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 }
 
 func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var model SecretResourceModel
-	var secretValue string
-	var valueWOVersion int32
+	// Fail up front because the checks before writing would fail on the fake secrets of the mock client
+	if r.mockMode {
+		resp.Diagnostics.AddError("Mock Mode Enabled", errMockMode.Error())
+		return
+	}
+
+	var model, config, state SecretResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value_wo"), &secretValue)...)
-	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("value_wo_version"), &valueWOVersion)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, LogKeyResourceID, model.ID.ValueString())
 
+	resp.Diagnostics.Append(resolveExpirationDate(&model)...)
+
 	tags, diags := toMap(model.Tags)
 	resp.Diagnostics.Append(diags...)
+	tags = mergeDefaultTags(r.defaultTags, tags)
 
 	attrs, diags := buildSecretAttributes(model)
 	resp.Diagnostics.Append(diags...)
@@ -285,12 +816,33 @@ func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest,
 	keyVaultID := model.KeyVaultID.ValueString()
 	name := model.Name.ValueString()
 
-	if valueWOVersion != model.ValueWOVersion.ValueInt32() {
+	written, diags := getWrittenSecret(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed, _ := valueWOTriggerChanged(model, state)
+	if config.hasValueSource() && (changed || valueChecksumDrifted(model, state, written.valueChecksum) || versionChangedExternally(model, state, written.version)) {
+		secretValue, diags := secretValueFromConfig(ctx, config)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
+		if model.TrackValueChecksum.ValueBool() {
+			tags[valueChecksumTagName] = to.Ptr(valueChecksum(secretValue))
+		}
+
+		if model.ContentType.IsUnknown() {
+			model.ContentType = types.StringValue(inferContentType(secretValue))
+		}
+
+		setValueStats(&model, secretValue)
+
+		model.PreviousVersion = state.Version
+		model.PreviousID = state.versionedID()
+
 		setResp, err := r.client.SetSecret(ctx, keyVaultID, name, azsecrets.SetSecretParameters{
 			Value:            to.Ptr(secretValue),
 			ContentType:      model.ContentType.ValueStringPointer(),
@@ -300,14 +852,53 @@ func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Failed to Set Secret",
-				"An unexpected error occurred while setting a secret: "+err.Error(),
+				"An unexpected error occurred while setting a secret: "+err.Error()+azureErrorHint(err, dataActionSetSecret),
 			)
 			return
 		}
 
-		resp.Diagnostics.Append(setSecretData(&model, setResp.ID, setResp.Attributes, setResp.ContentType, setResp.Tags)...)
+		resp.Diagnostics.Append(r.setResourceData(ctx, &model, setResp.ID, setResp.Attributes, setResp.ContentType, setResp.Tags)...)
+		resp.Diagnostics.Append(setWrittenSecret(ctx, resp.Private, newWrittenSecret(model, secretValue))...)
+		resp.Diagnostics.Append(r.publishEvent(ctx, eventTypeSecretNewVersionCreated, keyVaultID, name, model.Version.ValueString())...)
+		resp.Diagnostics.Append(r.pruneVersions(ctx, model)...)
+
+		err = r.client.NotifyRotation(ctx, RotationEvent{
+			KeyVaultID:      keyVaultID,
+			Name:            name,
+			Version:         model.Version.ValueString(),
+			PreviousVersion: model.PreviousVersion.ValueString(),
+			ExpirationDate:  model.ExpirationDate.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Failed to Notify Rotation",
+				fmt.Sprintf("The secret %q was rotated, but the rotation webhook failed: %s", name, err),
+			)
+		}
+	} else if secretPropertiesUnchanged(model, state) {
+		// Avoid throttling when only the attributes not stored in Key Vault change
+		tflog.Debug(ctx, "Skipped updating the secret properties because they are unchanged")
+		copySecretProperties(&model, state)
+		// stable_id may be the only change
+		setStableID(&model)
+
+		written.version = model.Version.ValueString()
+		resp.Diagnostics.Append(setWrittenSecret(ctx, resp.Private, written)...)
 	} else {
-		updateResp, err := r.client.UpdateSecretProperties(ctx, keyVaultID, name, model.Version.ValueString(), azsecrets.UpdateSecretPropertiesParameters{
+		// Keep the checksum of the current version
+		if model.TrackValueChecksum.ValueBool() && !state.ValueChecksum.IsNull() {
+			tags[valueChecksumTagName] = state.ValueChecksum.ValueStringPointer()
+		}
+
+		version := model.Version.ValueString()
+		if !model.PinnedVersion.Equal(state.PinnedVersion) {
+			// An empty version means the latest version
+			version = model.PinnedVersion.ValueString()
+		}
+
+		clearRemovedSecretAttributes(attrs, model, state)
+
+		updateResp, err := r.client.UpdateSecretProperties(ctx, keyVaultID, name, version, azsecrets.UpdateSecretPropertiesParameters{
 			ContentType:      model.ContentType.ValueStringPointer(),
 			SecretAttributes: attrs,
 			Tags:             tags,
@@ -315,14 +906,20 @@ func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Failed to Update Secret Properties",
-				"An unexpected error occurred while updating secret properties: "+err.Error(),
+				"An unexpected error occurred while updating secret properties: "+err.Error()+azureErrorHint(err, dataActionUpdate),
 			)
 			return
 		}
 
-		resp.Diagnostics.Append(setSecretData(&model, updateResp.ID, updateResp.Attributes, updateResp.ContentType, updateResp.Tags)...)
+		resp.Diagnostics.Append(r.setResourceData(ctx, &model, updateResp.ID, updateResp.Attributes, updateResp.ContentType, updateResp.Tags)...)
+
+		// The version is unchanged, but record it for resources written before the version was recorded
+		written.version = model.Version.ValueString()
+		resp.Diagnostics.Append(setWrittenSecret(ctx, resp.Private, written)...)
 	}
 
+	resp.Diagnostics.Append(r.setVersions(ctx, &model)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -336,15 +933,128 @@ func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	ctx = tflog.SetField(ctx, LogKeyResourceID, state.ID.ValueString())
 
-	if _, err := r.client.DeleteSecret(ctx, state.KeyVaultID.ValueString(), state.Name.ValueString(), nil); err != nil {
+	keyVaultID := state.KeyVaultID.ValueString()
+	name := state.Name.ValueString()
+
+	switch state.DeleteBehavior.ValueString() {
+	case deleteBehaviorAbandon:
+		tflog.Debug(ctx, "Removed the secret from the state without deleting it")
+		return
+	case deleteBehaviorDisable:
+		// Disable all the versions, since the version in the state may not be the one that applications read,
+		// e.g. if track_latest_version is false or a new version has been created outside of Terraform
+		tflog.Debug(ctx, "Disabling the secret instead of deleting it")
+		disabled, err := pruneSecretVersions(ctx, r.client, keyVaultID, name, 0, nil)
+		if len(disabled) > 0 {
+			tflog.Debug(ctx, "Disabled the versions of the secret", map[string]any{"versions": disabled})
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Disable Secret",
+				"An unexpected error occurred while disabling a secret: "+err.Error()+azureErrorHint(err, dataActionUpdate),
+			)
+		}
+		return
+	}
+
+	if _, err := r.client.DeleteSecret(ctx, keyVaultID, name, nil); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to Delete Secret",
-			"An unexpected error occurred while deleting a secret: "+err.Error(),
+			"An unexpected error occurred while deleting a secret: "+err.Error()+azureErrorHint(err, dataActionDelete),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.publishEvent(ctx, eventTypeSecretDeleted, keyVaultID, name, "")...)
+
+	purge := r.purgeSoftDeleteOnDestroy
+	if !state.PurgeSoftDeleteOnDestroy.IsNull() {
+		purge = state.PurgeSoftDeleteOnDestroy.ValueBool()
+	}
+	if !purge {
+		return
+	}
+
+	tflog.Debug(ctx, "Purging the deleted secret")
+	if _, err := r.client.PurgeDeletedSecret(ctx, keyVaultID, name, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Purge Secret",
+			"The secret was deleted, but an unexpected error occurred while purging it: "+err.Error()+azureErrorHint(err, dataActionPurge),
 		)
 		return
 	}
 }
 
+// pruneVersions disables the versions beyond max_versions_to_keep.
+// Failures are reported as warnings because the new version has already been created.
+func (r *SecretResource) pruneVersions(ctx context.Context, model SecretResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.MaxVersionsToKeep.IsNull() {
+		return diags
+	}
+
+	tags, tagsDiags := toMap(model.PrunedVersionTags)
+	diags.Append(tagsDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	disabled, err := pruneSecretVersions(ctx, r.client, model.KeyVaultID.ValueString(), model.Name.ValueString(), int(model.MaxVersionsToKeep.ValueInt32()), tags)
+	if len(disabled) > 0 {
+		tflog.Debug(ctx, "Disabled the old versions of the secret", map[string]any{"versions": disabled})
+	}
+	if err != nil {
+		diags.AddWarning(
+			"Failed to Prune Secret Versions",
+			"The secret was set, but an unexpected error occurred while disabling the old versions: "+err.Error()+azureErrorHint(err, dataActionUpdate),
+		)
+	}
+
+	return diags
+}
+
+// setVersions sets versions if include_versions is true.
+// Failures are reported as warnings because versions is only informational.
+func (r *SecretResource) setVersions(ctx context.Context, model *SecretResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Versions = types.ListNull(types.ObjectType{AttrTypes: secretVersionAttrTypes})
+	if !model.IncludeVersions.ValueBool() {
+		return diags
+	}
+
+	versions, err := r.client.ListSecretPropertiesVersions(ctx, model.KeyVaultID.ValueString(), model.Name.ValueString())
+	if err != nil {
+		diags.AddWarning(
+			"Failed to List Secret Versions",
+			"An unexpected error occurred while listing the versions of a secret: "+err.Error()+azureErrorHint(err, dataActionReadMetadata),
+		)
+		return diags
+	}
+
+	model.Versions, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: secretVersionAttrTypes}, newSecretVersions(versions))
+	return diags
+}
+
+// setResourceData sets the secret data to the model in the same way as setSecretData,
+// separating the checksum tag and the default tags from the tags.
+func (r *SecretResource) setResourceData(ctx context.Context, model *SecretResourceModel, id *azsecrets.ID, attrs *azsecrets.SecretAttributes, contentType *string, tags map[string]*string) diag.Diagnostics {
+	managedTags, diags := toMap(model.Tags)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(setSecretData(model, id, attrs, contentType, tags)...)
+	setStableID(model)
+	diags.Append(popValueChecksumTag(ctx, model)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(splitDefaultTags(ctx, model, managedTags, r.defaultTags)...)
+	return diags
+}
+
 func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var keyVaultID string
 
@@ -357,33 +1067,53 @@ func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportSta
 
 		name := identity.Name.ValueString()
 		keyVaultID = identity.KeyVaultID.ValueString()
-		secretProperties, err := r.client.GetSecretProperties(ctx, keyVaultID, name, "", nil)
+		if _, err := extractVaultName(keyVaultID); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Identity",
+				err.Error(),
+			)
+			return
+		}
+		if !identity.SubscriptionID.IsNull() {
+			if want := newSecretResourceIdentity(identity.Name, identity.KeyVaultID).SubscriptionID; !identity.SubscriptionID.Equal(want) {
+				resp.Diagnostics.AddError(
+					"Invalid Identity",
+					fmt.Sprintf("The subscription ID %q doesn't match that of the key vault ID %q", identity.SubscriptionID.ValueString(), keyVaultID),
+				)
+				return
+			}
+		}
+		// Pin the version if it is specified
+		version := identity.Version.ValueString()
+		secretProperties, err := r.client.GetSecretProperties(ctx, keyVaultID, name, version, nil)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error())
+			resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
+		if err := checkImportedSecretID(r.client.VaultURL, keyVaultID, name, secretProperties.ID); err != nil {
+			resp.Diagnostics.AddError(
+				"Inconsistent Import",
+				err.Error(),
+			)
 			return
 		}
 
 		ctx = tflog.SetField(ctx, LogKeyResourceID, secretProperties.ID)
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), secretProperties.ID)...)
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+		if version != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pinned_version"), version)...)
+		}
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	} else {
 		ctx = tflog.SetField(ctx, LogKeyResourceID, req.ID)
 
-		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-
-		if r.client.GetSubscriptionID() == "" {
-			resp.Diagnostics.AddError(
-				"Missing Configuration",
-				"Subscription ID is required to import a secret",
-			)
-			return
-		}
-
 		// Set key_vault_id manually because the configuration value is not accessible
 		// cf. https://discuss.hashicorp.com/t/access-resource-configuration-in-plugin-framework-read/57440
+		// The ID can be either versioned or versionless.
+		// The key vault is looked up in all the subscriptions accessible with the credential if it isn't in the subscription of the provider.
 		vaultName, name, err := extractVaultNameAndName(req.ID)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -393,33 +1123,270 @@ func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportSta
 			return
 		}
 
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
-		if resp.Diagnostics.HasError() {
+		keyVaultID, err = r.client.GetKeyVaultID(ctx, vaultName, &GetKeyVaultIDOptions{SearchAllSubscriptions: true})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to Get KeyVaults",
+				err.Error()+azureRequestDetails(err),
+			)
+			return
+		}
+		if resolved, err := extractVaultName(keyVaultID); err != nil || !strings.EqualFold(resolved, vaultName) {
+			resp.Diagnostics.AddError(
+				"Inconsistent Import",
+				fmt.Sprintf("The key vault ID %q resolved from the ID %q doesn't point to the key vault %q", keyVaultID, req.ID, vaultName),
+			)
 			return
 		}
 
-		keyVaultID, err = r.client.GetKeyVaultID(ctx, vaultName)
+		// Pin the version if the ID is versioned
+		version := to.Ptr(azsecrets.ID(req.ID)).Version()
+		secretProperties, err := r.client.GetSecretProperties(ctx, keyVaultID, name, version, nil)
 		if err != nil {
+			resp.Diagnostics.AddError("Failed to Get Secret Properties", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
+		if err := checkImportedSecretID(r.client.VaultURL, keyVaultID, name, secretProperties.ID); err != nil {
 			resp.Diagnostics.AddError(
-				"Failed to Get KeyVaults",
+				"Inconsistent Import",
 				err.Error(),
 			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), secretProperties.ID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+		if version != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pinned_version"), version)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_vault_id"), keyVaultID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value_wo_version"), 1)...)
+	resp.Diagnostics.Append(setUnmanagedAttributes(ctx, &resp.State)...)
+}
+
+func (r *SecretResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: r.moveStateFromAzapiResource,
+		},
+		{
+			StateMover: r.moveStateFromAzurermKeyVaultSecret,
+		},
+	}
+}
+
+// moveStateFromAzapiResource moves the state of azapi_resource whose type is Microsoft.KeyVault/vaults/secrets.
+func (r *SecretResource) moveStateFromAzapiResource(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "azapi_resource" || !strings.HasSuffix(req.SourceProviderAddress, "/azure/azapi") {
+		return
+	}
+
+	var source struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Invalid Source State", "An unexpected error occurred while parsing the state of azapi_resource: "+err.Error())
+		return
+	}
+
+	resourceType, _, _ := strings.Cut(source.Type, "@")
+	if !strings.EqualFold(resourceType, "Microsoft.KeyVault/vaults/secrets") {
+		resp.Diagnostics.AddError(
+			"Unsupported Source Resource Type",
+			fmt.Sprintf("Only azapi_resource of the type Microsoft.KeyVault/vaults/secrets can be moved to this resource, got: %q", source.Type),
+		)
+		return
+	}
+
+	id, err := arm.ParseResourceID(source.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Source State", "An unexpected error occurred while parsing the ID of azapi_resource: "+err.Error())
+		return
+	}
+
+	keyVaultID := id.Parent.String()
+	vaultName, err := extractVaultName(keyVaultID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Source State", err.Error())
+		return
+	}
+
+	vaultURL := defaultVaultURL(vaultName)
+	if r.client != nil {
+		vaultURL = r.client.VaultURL(vaultName)
+	}
+
+	// The other attributes are set on the next refresh
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("id"), vaultURL+"/secrets/"+id.Name)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("name"), id.Name)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("key_vault_id"), keyVaultID)...)
+	resp.Diagnostics.Append(setUnmanagedAttributes(ctx, &resp.TargetState)...)
+
+	identity := newSecretResourceIdentity(types.StringValue(id.Name), types.StringValue(keyVaultID))
+	resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, identity)...)
+}
+
+// moveStateFromAzurermKeyVaultSecret moves the state of azurerm_key_vault_secret.
+func (r *SecretResource) moveStateFromAzurermKeyVaultSecret(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "azurerm_key_vault_secret" || !strings.HasSuffix(req.SourceProviderAddress, "/hashicorp/azurerm") {
+		return
+	}
+
+	var source struct {
+		ID             string            `json:"id"`
+		KeyVaultID     string            `json:"key_vault_id"`
+		Name           string            `json:"name"`
+		ContentType    string            `json:"content_type"`
+		NotBeforeDate  string            `json:"not_before_date"`
+		ExpirationDate string            `json:"expiration_date"`
+		Tags           map[string]string `json:"tags"`
+	}
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Invalid Source State", "An unexpected error occurred while parsing the state of azurerm_key_vault_secret: "+err.Error())
+		return
+	}
+
+	id := azsecrets.ID(source.ID)
+	if id.Name() == "" || id.Version() == "" {
+		resp.Diagnostics.AddError("Invalid Source State", fmt.Sprintf("The ID of azurerm_key_vault_secret must be a versioned secret ID, got: %q", source.ID))
+		return
+	}
+
+	// azurerm stores an empty string if the dates are not set
+	notBeforeDate, expirationDate := timetypes.NewRFC3339Null(), timetypes.NewRFC3339Null()
+	if source.NotBeforeDate != "" {
+		var diags diag.Diagnostics
+		notBeforeDate, diags = timetypes.NewRFC3339Value(source.NotBeforeDate)
+		resp.Diagnostics.Append(diags...)
+	}
+	if source.ExpirationDate != "" {
+		var diags diag.Diagnostics
+		expirationDate, diags = timetypes.NewRFC3339Value(source.ExpirationDate)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tags := source.Tags
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	// The other attributes are set on the next refresh
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("id"), source.ID)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("versioned_id"), source.ID)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("versionless_id"), strings.TrimSuffix(source.ID, "/"+id.Version()))...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("version"), id.Version())...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("name"), source.Name)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("key_vault_id"), source.KeyVaultID)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("content_type"), source.ContentType)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("not_before_date"), notBeforeDate)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("expiration_date"), expirationDate)...)
+	resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("tags"), tags)...)
+	resp.Diagnostics.Append(setUnmanagedAttributes(ctx, &resp.TargetState)...)
+
+	identity := newSecretResourceIdentity(types.StringValue(source.Name), types.StringValue(source.KeyVaultID))
+	resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, identity)...)
+}
+
+// attributeSetter is implemented by both tfsdk.State and tfsdk.Resource.
+type attributeSetter interface {
+	SetAttribute(ctx context.Context, path path.Path, val any) diag.Diagnostics
+}
+
+// setUnmanagedAttributes sets the attributes that don't exist in Key Vault for imported, moved, or listed resources.
+// value_wo_version is set to 1 so that adding value_wo with value_wo_version = 1 to the generated configuration doesn't write the value.
+func setUnmanagedAttributes(ctx context.Context, state attributeSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(state.SetAttribute(ctx, path.Root("value_wo_version"), types.DynamicValue(types.Int64Value(1)))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("overwrite_existing"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("delete_behavior"), deleteBehaviorDelete)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("track_value_checksum"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("detect_external_changes"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("infer_content_type"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("track_latest_version"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("include_versions"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("stable_id"), false)...)
+
+	return diags
 }
 
 func (r *SecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	if req.Config.Raw.IsNull() || // This resource will be deleted
-		req.State.Raw.IsNull() { // This resource will be created
+	if req.Config.Raw.IsNull() { // This resource will be deleted
 		return
 	}
 
 	var config, state SecretResourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modifyKeyVaultIDPlan(ctx, config, req.State, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Name.IsUnknown() {
+		if err := checkNamePattern(r.namePattern, config.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid Secret Name", err.Error())
+			return
+		}
+	}
+
+	var trackValueChecksum types.Bool
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("track_value_checksum"), &trackValueChecksum)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The checksum tag is also stored in Key Vault
+	modifyTagsAllPlan(ctx, resp, r.defaultTags, r.requiredTags, trackValueChecksum.ValueBool())
+
+	if !config.ValueJSONWO.IsNull() && config.ContentType.IsNull() && !config.InferContentType.ValueBool() {
+		resp.Plan.SetAttribute(ctx, path.Root("content_type"), jsonContentType)
+	}
+
+	if !config.IncludeVersions.IsUnknown() && !config.IncludeVersions.ValueBool() {
+		resp.Plan.SetAttribute(ctx, path.Root("versions"), types.ListNull(types.ObjectType{AttrTypes: secretVersionAttrTypes}))
+	}
+
+	if req.State.Raw.IsNull() { // This resource will be created
+		if !config.hasValueSource() {
+			resp.Diagnostics.AddError(
+				"Missing Attribute Configuration",
+				"One of value, value_wo, value_json_wo, value_source_env, or value_source_command must be specified to create a secret. "+
+					"The value can be omitted only for imported resources.",
+			)
+			return
+		}
+		if !config.PinnedVersion.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pinned_version"),
+				"Invalid Attribute Combination",
+				"pinned_version can only be set for imported resources. Import the secret with its versioned ID to pin the version.",
+			)
+			return
+		}
+		if !config.TrackValueChecksum.ValueBool() {
+			resp.Plan.SetAttribute(ctx, path.Root("value_checksum"), types.StringNull())
+		}
+		if config.InferContentType.ValueBool() {
+			resp.Plan.SetAttribute(ctx, path.Root("content_type"), types.StringUnknown())
+		}
+		resp.Plan.SetAttribute(ctx, path.Root("previous_version"), types.StringNull())
+		resp.Plan.SetAttribute(ctx, path.Root("previous_id"), types.StringNull())
+		modifyExpirationDatePlan(ctx, config, state, true, resp)
+		r.warnExpiration(ctx, resp)
+		return
+	}
+
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -427,26 +1394,229 @@ func (r *SecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 
 	ctx = tflog.SetField(ctx, LogKeyResourceID, state.ID.ValueString())
 
-	if config.ValueWO.IsNull() || config.ValueWOVersion.IsNull() {
-		tflog.Debug(ctx, "The secret value will not be be updated because the change of the value_wo or value_wo_version seem to be ignored by the lifecycle")
+	written, diags := getWrittenSecret(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if config.ValueWO.IsUnknown() {
-		tflog.Debug(ctx, "The secret value will be updated because the value is unknown")
-		markValueWillChange(ctx, resp)
+	valueWillChange := secretValueWillChange(ctx, config, state, written)
+	if valueWillChange && !config.PinnedVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pinned_version"),
+			"Invalid Attribute Combination",
+			"The value of the pinned version can't be updated. Remove pinned_version to create a new version.",
+		)
 		return
 	}
 
-	if config.ValueWOVersion != state.ValueWOVersion {
-		tflog.Debug(ctx, "The secret value will be updated because the value_wo_version changes")
+	// Changing pinned_version also changes the version to manage
+	versionWillChange := valueWillChange || !config.PinnedVersion.Equal(state.PinnedVersion)
+	if versionWillChange {
 		markValueWillChange(ctx, resp)
+		if config.StableID.ValueBool() {
+			resp.Plan.SetAttribute(ctx, path.Root("id"), state.VersionlessID)
+		}
+	} else {
+		id := state.versionedID()
+		if config.StableID.ValueBool() {
+			id = state.VersionlessID
+		}
+		resp.Plan.SetAttribute(ctx, path.Root("id"), id)
+		resp.Plan.SetAttribute(ctx, path.Root("versioned_id"), state.versionedID())
+		resp.Plan.SetAttribute(ctx, path.Root("resource_id"), state.ResourceID.ValueString())
+		resp.Plan.SetAttribute(ctx, path.Root("version"), state.Version.ValueString())
+	}
+
+	// The current version becomes the previous version when a new version is created
+	if valueWillChange {
+		resp.Plan.SetAttribute(ctx, path.Root("previous_version"), state.Version)
+		resp.Plan.SetAttribute(ctx, path.Root("previous_id"), state.versionedID())
+	} else {
+		resp.Plan.SetAttribute(ctx, path.Root("previous_version"), state.PreviousVersion)
+		resp.Plan.SetAttribute(ctx, path.Root("previous_id"), state.PreviousID)
+	}
+
+	// The content type is inferred only when a new version is created
+	if config.InferContentType.ValueBool() {
+		if valueWillChange {
+			resp.Plan.SetAttribute(ctx, path.Root("content_type"), types.StringUnknown())
+		} else {
+			resp.Plan.SetAttribute(ctx, path.Root("content_type"), state.ContentType)
+		}
+	}
+
+	switch {
+	case !config.TrackValueChecksum.ValueBool():
+		resp.Plan.SetAttribute(ctx, path.Root("value_checksum"), types.StringNull())
+	case versionWillChange:
+		resp.Plan.SetAttribute(ctx, path.Root("value_checksum"), types.StringUnknown())
+	default:
+		resp.Plan.SetAttribute(ctx, path.Root("value_checksum"), state.ValueChecksum)
+	}
+
+	switch {
+	case valueWillChange:
+		resp.Plan.SetAttribute(ctx, path.Root("value_length"), types.Int64Unknown())
+		resp.Plan.SetAttribute(ctx, path.Root("value_entropy_class"), types.StringUnknown())
+	case versionWillChange:
+		// The value of another version is unknown to this provider
+		resp.Plan.SetAttribute(ctx, path.Root("value_length"), types.Int64Null())
+		resp.Plan.SetAttribute(ctx, path.Root("value_entropy_class"), types.StringNull())
+	default:
+		resp.Plan.SetAttribute(ctx, path.Root("value_length"), state.ValueLength)
+		resp.Plan.SetAttribute(ctx, path.Root("value_entropy_class"), state.ValueEntropyClass)
+	}
+
+	modifyExpirationDatePlan(ctx, config, state, valueWillChange, resp)
+	r.warnExpiration(ctx, resp)
+}
+
+// modifyKeyVaultIDPlan plans key_vault_id resolved from vault_name, vault_uri, vault_alias, or key_vault_id of the provider.
+// The ID in the state is reused as long as vault_name and vault_uri are unchanged to avoid listing key vaults on every plan,
+// while the others are always resolved so that changes of the provider configuration are planned.
+func (r *SecretResource) modifyKeyVaultIDPlan(ctx context.Context, config SecretResourceModel, state tfsdk.State, resp *resource.ModifyPlanResponse) {
+	if !config.KeyVaultID.IsNull() || config.VaultName.IsUnknown() || config.VaultURI.IsUnknown() || config.VaultAlias.IsUnknown() {
+		return
+	}
+
+	var stateKeyVaultID types.String
+	if !state.Raw.IsNull() {
+		var stateModel SecretResourceModel
+		resp.Diagnostics.Append(state.Get(ctx, &stateModel)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// key_vault_id is already planned from the state by UseStateForUnknown
+		stateKeyVaultID = stateModel.KeyVaultID
+		if (!config.VaultName.IsNull() || !config.VaultURI.IsNull()) && config.VaultName.Equal(stateModel.VaultName) && config.VaultURI.Equal(stateModel.VaultURI) {
+			return
+		}
+	}
+
+	keyVaultID, err := config.resolveKeyVaultID(ctx, r.client, r.vaultAliases, r.defaultKeyVaultID)
+	if errors.Is(err, errMissingKeyVault) {
+		resp.Diagnostics.AddError("Missing Key Vault", "One of key_vault_id, vault_name, vault_uri, or vault_alias must be specified unless key_vault_id is set on the provider.")
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Get KeyVaults", err.Error()+azureRequestDetails(err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("key_vault_id"), keyVaultID)...)
+	if !stateKeyVaultID.IsNull() && stateKeyVaultID.ValueString() != keyVaultID {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("key_vault_id"))
+	}
+}
+
+// warnExpiration adds a warning if the planned expiration_date is within expiration_warning_days of the provider.
+func (r *SecretResource) warnExpiration(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	if r.expirationWarningDays == 0 || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var expirationDate timetypes.RFC3339
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("expiration_date"), &expirationDate)...)
+	if resp.Diagnostics.HasError() || expirationDate.IsNull() || expirationDate.IsUnknown() {
+		return
+	}
+
+	expires, diags := expirationDate.ValueRFC3339Time()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if time.Until(expires) < time.Duration(r.expirationWarningDays)*24*time.Hour {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expiration_date"),
+			"Secret Expires Soon",
+			fmt.Sprintf("The secret expires at %s, which is within %d days. Consider rotating the secret or extending the expiration date.", expirationDate.ValueString(), r.expirationWarningDays),
+		)
+	}
+}
+
+func secretValueWillChange(ctx context.Context, config, state SecretResourceModel, written writtenSecret) bool {
+	if !config.hasValueSource() ||
+		(config.Value.IsNull() && config.ValueWOVersion.IsNull() && config.ValueWOTrigger.IsNull() && config.Triggers.IsNull()) {
+		tflog.Debug(ctx, "The secret value will not be be updated because the change of the value, value_wo, value_wo_version, value_wo_trigger, or triggers seem to be ignored by the lifecycle")
+		return false
+	}
+
+	if config.Value.IsUnknown() || config.ValueWO.IsUnknown() || config.ValueJSONWO.IsUnknown() {
+		tflog.Debug(ctx, "The secret value will be updated because the value is unknown")
+		return true
+	}
+
+	if changed, attr := valueWOTriggerChanged(config, state); changed {
+		tflog.Debug(ctx, "The secret value will be updated because the "+attr+" changes")
+		return true
+	}
+
+	if valueChecksumDrifted(config, state, written.valueChecksum) {
+		tflog.Debug(ctx, "The secret value will be updated because the checksum differs from that of the value written by this provider", map[string]any{"value_checksum": state.ValueChecksum.ValueString()})
+		return true
+	}
+
+	if versionChangedExternally(config, state, written.version) {
+		tflog.Debug(ctx, "The secret value will be updated because a version is created outside of Terraform", map[string]any{"version": state.Version.ValueString()})
+		return true
+	}
+
+	return false
+}
+
+// modifyExpirationDatePlan plans expiration_date, which is computed from expires_in
+// when a new version is created, expires_in changes, or the expiration date is within rolling_expiration_days.
+func modifyExpirationDatePlan(ctx context.Context, config, state SecretResourceModel, valueWillChange bool, resp *resource.ModifyPlanResponse) {
+	if !config.ExpirationDate.IsNull() {
+		return
+	}
+
+	if config.ExpiresIn.IsNull() {
+		resp.Plan.SetAttribute(ctx, path.Root("expiration_date"), timetypes.NewRFC3339Null())
+		return
+	}
+
+	if !config.ExpiresIn.IsUnknown() {
+		expiresIn, diags := config.ExpiresIn.ValueGoDuration()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if expiresIn <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_in"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("expires_in must be a positive duration, got: %s", config.ExpiresIn.ValueString()),
+			)
+			return
+		}
+	}
+
+	if valueWillChange || !config.ExpiresIn.Equal(state.ExpiresIn) {
+		resp.Plan.SetAttribute(ctx, path.Root("expiration_date"), timetypes.NewRFC3339Unknown())
 		return
 	}
 
-	resp.Plan.SetAttribute(ctx, path.Root("id"), state.ID.ValueString())
-	resp.Plan.SetAttribute(ctx, path.Root("resource_id"), state.ResourceID.ValueString())
-	resp.Plan.SetAttribute(ctx, path.Root("version"), state.Version.ValueString())
+	if !config.RollingExpirationDays.IsNull() && !config.RollingExpirationDays.IsUnknown() && !state.ExpirationDate.IsNull() {
+		expires, diags := state.ExpirationDate.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		threshold := time.Duration(config.RollingExpirationDays.ValueInt32()) * 24 * time.Hour
+		if time.Until(expires) < threshold {
+			tflog.Debug(ctx, "The expiration date will be extended because it is within rolling_expiration_days", map[string]any{"expiration_date": state.ExpirationDate.ValueString()})
+			resp.Plan.SetAttribute(ctx, path.Root("expiration_date"), timetypes.NewRFC3339Unknown())
+			return
+		}
+	}
+
+	resp.Plan.SetAttribute(ctx, path.Root("expiration_date"), state.ExpirationDate)
 }
 
 func toMap(m types.Map) (map[string]*string, diag.Diagnostics) {
@@ -455,6 +1625,22 @@ func toMap(m types.Map) (map[string]*string, diag.Diagnostics) {
 	return ret, diags
 }
 
+// resolveExpirationDate sets expiration_date computed from expires_in if it is unknown.
+func resolveExpirationDate(model *SecretResourceModel) diag.Diagnostics {
+	if !model.ExpirationDate.IsUnknown() {
+		return nil
+	}
+
+	if model.ExpiresIn.IsNull() {
+		model.ExpirationDate = timetypes.NewRFC3339Null()
+		return nil
+	}
+
+	expiresIn, diags := model.ExpiresIn.ValueGoDuration()
+	model.ExpirationDate = timetypes.NewRFC3339TimeValue(time.Now().Add(expiresIn).UTC().Truncate(time.Second))
+	return diags
+}
+
 func buildSecretAttributes(model SecretResourceModel) (*azsecrets.SecretAttributes, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var expires, notBefore time.Time
@@ -477,9 +1663,83 @@ func buildSecretAttributes(model SecretResourceModel) (*azsecrets.SecretAttribut
 	return &attrs, diags
 }
 
+// clearRemovedSecretAttributes sets explicit null values to the dates removed from the configuration,
+// because the omitted attributes are left unchanged by UpdateSecretProperties.
+func clearRemovedSecretAttributes(attrs *azsecrets.SecretAttributes, model, state SecretResourceModel) {
+	if model.ExpirationDate.IsNull() && !state.ExpirationDate.IsNull() {
+		attrs.Expires = azcore.NullValue[*time.Time]()
+	}
+	if model.NotBeforeDate.IsNull() && !state.NotBeforeDate.IsNull() {
+		attrs.NotBefore = azcore.NullValue[*time.Time]()
+	}
+}
+
+// valueWOTriggerChanged reports whether value, value_wo_version, value_wo_trigger, or triggers changes and which one changes.
+// Switching from one to the other doesn't update the secret value.
+func valueWOTriggerChanged(config, state SecretResourceModel) (bool, string) {
+	if !config.Value.IsNull() && !state.Value.IsNull() && !config.Value.Equal(state.Value) {
+		return true, "value"
+	}
+	return config.valueWOTriggers().changedFrom(state.valueWOTriggers())
+}
+
+func (m SecretResourceModel) valueWOTriggers() valueWOTriggers {
+	return valueWOTriggers{version: m.ValueWOVersion, trigger: m.ValueWOTrigger, triggers: m.Triggers}
+}
+
+// secretPropertiesUnchanged reports whether the planned properties stored in Key Vault are the same as the current ones.
+func secretPropertiesUnchanged(model, state SecretResourceModel) bool {
+	return model.PinnedVersion.Equal(state.PinnedVersion) &&
+		model.ContentType.Equal(state.ContentType) &&
+		model.NotBeforeDate.Equal(state.NotBeforeDate) &&
+		model.ExpirationDate.Equal(state.ExpirationDate) &&
+		model.TagsAll.Equal(state.TagsAll) &&
+		model.TrackValueChecksum.Equal(state.TrackValueChecksum)
+}
+
+// versionedID returns the ID of the current version.
+// It is derived from versionless_id and version because versioned_id is null in the state written before it was added.
+func (m SecretResourceModel) versionedID() types.String {
+	if m.VersionlessID.IsNull() || m.Version.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(m.VersionlessID.ValueString() + "/" + m.Version.ValueString())
+}
+
+// setStableID sets versioned_id, and id according to stable_id.
+func setStableID(model *SecretResourceModel) {
+	model.VersionedID = model.versionedID()
+	if model.StableID.ValueBool() {
+		model.ID = model.VersionlessID
+	} else {
+		model.ID = model.VersionedID
+	}
+}
+
+// copySecretProperties copies the attributes read from Key Vault.
+func copySecretProperties(model *SecretResourceModel, state SecretResourceModel) {
+	model.ID = state.ID
+	model.VersionlessID = state.VersionlessID
+	model.Version = state.Version
+	model.ResourceID = state.ResourceID
+	model.ResourceVersionlessID = state.ResourceVersionlessID
+	model.ContentType = state.ContentType
+	model.NotBeforeDate = state.NotBeforeDate
+	model.ExpirationDate = state.ExpirationDate
+	model.TagsAll = state.TagsAll
+	model.ValueChecksum = state.ValueChecksum
+}
+
+// versionChangedExternally reports whether the current version differs from the version written by this provider
+// if detect_external_changes is true.
+func versionChangedExternally(model, state SecretResourceModel, writtenVersion string) bool {
+	return model.DetectExternalChanges.ValueBool() && writtenVersion != "" && state.Version.ValueString() != writtenVersion
+}
+
 func markValueWillChange(ctx context.Context, resp *resource.ModifyPlanResponse) {
 	// When the value changes, these attributes also change
 	resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())
+	resp.Plan.SetAttribute(ctx, path.Root("versioned_id"), types.StringUnknown())
 	resp.Plan.SetAttribute(ctx, path.Root("resource_id"), types.StringUnknown())
 	resp.Plan.SetAttribute(ctx, path.Root("version"), types.StringUnknown())
 }
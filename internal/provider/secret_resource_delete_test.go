@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	azsecretsfake "github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets/fake"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSecretResourceDeleteWithDisable(t *testing.T) {
+	t.Parallel()
+
+	disabledVersion := secretProperties("version-0", 50)
+	disabledVersion.Attributes.Enabled = to.Ptr(false)
+	versions := []*azsecrets.SecretProperties{
+		disabledVersion,
+		secretProperties("version-1", 100),
+		// The latest version created outside of Terraform
+		secretProperties("version-2", 200),
+	}
+
+	var gotDisabled []string
+	fakeServer := azsecretsfake.Server{
+		NewListSecretPropertiesVersionsPager: func(
+			_ string,
+			_ *azsecrets.ListSecretPropertiesVersionsOptions,
+		) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+			resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+				SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{
+					Value: versions,
+				},
+			}, nil)
+			return
+		},
+		UpdateSecretProperties: func(
+			_ context.Context,
+			name string,
+			version string,
+			parameters azsecrets.UpdateSecretPropertiesParameters,
+			_ *azsecrets.UpdateSecretPropertiesOptions,
+		) (resp azfake.Responder[azsecrets.UpdateSecretPropertiesResponse], errResp azfake.ErrorResponder) {
+			// The fake server's route pattern also matches "/" in the secret name, so the version may be a part of it
+			if _, v, ok := strings.Cut(name, "/"); ok {
+				version = v
+			}
+			if parameters.SecretAttributes == nil || parameters.SecretAttributes.Enabled == nil || *parameters.SecretAttributes.Enabled {
+				t.Errorf("UpdateSecretProperties() doesn't disable the version %q", version)
+			}
+			gotDisabled = append(gotDisabled, version)
+			resp.SetResponse(http.StatusOK, azsecrets.UpdateSecretPropertiesResponse{}, nil)
+			return
+		},
+		DeleteSecret: func(
+			_ context.Context,
+			_ string,
+			_ *azsecrets.DeleteSecretOptions,
+		) (resp azfake.Responder[azsecrets.DeleteSecretResponse], errResp azfake.ErrorResponder) {
+			t.Error("DeleteSecret() is called unexpectedly")
+			return
+		},
+	}
+
+	ctx := context.Background()
+	r := &SecretResource{client: newTestClient(t, &fakeServer)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// The state has an older version than the latest one
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw: nullObjectValue(typ, map[string]tftypes.Value{
+			"name":                 tftypes.NewValue(tftypes.String, "secret-name"),
+			"key_vault_id":         tftypes.NewValue(tftypes.String, testKeyVaultID),
+			"version":              tftypes.NewValue(tftypes.String, "version-1"),
+			"delete_behavior":      tftypes.NewValue(tftypes.String, deleteBehaviorDisable),
+			"track_latest_version": tftypes.NewValue(tftypes.Bool, false),
+		}),
+	}
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	slices.Sort(gotDisabled)
+	if want := []string{"version-1", "version-2"}; !slices.Equal(gotDisabled, want) {
+		t.Errorf("disabled versions = %v, want %v", gotDisabled, want)
+	}
+}
@@ -2,18 +2,26 @@ package provider_test
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccSecretResource_basic(t *testing.T) {
 	t.Parallel()
 
 	rn := generateRandomName(23)
+	// versionedID is the ID used to import the secret with the version
+	var versionedID string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -27,23 +35,590 @@ func TestAccSecretResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			buildTestStep(basicResourceConfig(rn, 1)),
 			{
-				ResourceName:      "azurekv_secret.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            "azurekv_secret.test",
+				ImportState:             true,
+				ImportStateIdFunc:       importStateIDFromAttribute("azurekv_secret.test", "versionless_id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importStateVerifyIgnore,
 			},
+			// Importing with the versioned ID pins the version
+			{
+				ResourceName: "azurekv_secret.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					var err error
+					versionedID, err = importStateIDFromAttribute("azurekv_secret.test", "id")(s)
+					return versionedID, err
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: append(importStateVerifyIgnore, "pinned_version"),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(states))
+					}
+					version := path.Base(versionedID)
+					if got := states[0].Attributes["pinned_version"]; got != version {
+						return fmt.Errorf("pinned_version = %q, want %q", got, version)
+					}
+					if got := states[0].Attributes["version"]; got != version {
+						return fmt.Errorf("version = %q, want %q", got, version)
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:    "azurekv_secret.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// The generated configuration doesn't manage the value
 			{
 				ResourceName:    "azurekv_secret.test",
 				ImportState:     true,
 				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				GenerateConfig:  true,
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_complete(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			buildTestStep(completeResourceConfig(rn, 1)),
+			{
+				ResourceName:            "azurekv_secret.test",
+				ImportState:             true,
+				ImportStateIdFunc:       importStateIDFromAttribute("azurekv_secret.test", "versionless_id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importStateVerifyIgnore,
+			},
+			{
+				ResourceName:    "azurekv_secret.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_update(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			buildTestStep(basicResourceConfig(rn, 1)),
+			{
+				ResourceName:            "azurekv_secret.test",
+				ImportState:             true,
+				ImportStateIdFunc:       importStateIDFromAttribute("azurekv_secret.test", "versionless_id"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: importStateVerifyIgnore,
+			},
+			// Update the secret value
+			buildTestStep(basicResourceConfig(rn, 2)),
+			// Update the properties
+			buildTestStep(completeResourceConfig(rn, 2)),
+			// Clear the properties
+			buildTestStep(basicResourceConfig(rn, 2)),
+		},
+	})
+}
+
+func TestAccSecretResource_valueWOTrigger(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: valueWOTriggerResourceConfig(rn, "2025-01-23"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: valueWOTriggerResourceConfig(rn, "2025-02-23"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_valueWOVersionString(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: valueWOVersionStringResourceConfig(rn, "2025-01-23T00:00:00Z"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: valueWOVersionStringResourceConfig(rn, "2025-02-23T00:00:00Z"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_value(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: valueResourceConfig(rn, "secret-value"),
+				Check:  resource.TestCheckResourceAttr("azurekv_secret.test", "value", "secret-value"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: valueResourceConfig(rn, "new-secret-value"),
+				Check:  resource.TestCheckResourceAttr("azurekv_secret.test", "value", "new-secret-value"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_triggers(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+	previousVersion := statecheck.CompareValue(compare.ValuesSame())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: triggersResourceConfig(rn, "1.0.0"),
+				Check:  resource.TestCheckNoResourceAttr("azurekv_secret.test", "previous_version"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+					previousVersion.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Update the secret value
+			{
+				Config: triggersResourceConfig(rn, "1.1.0"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+					previousVersion.AddStateValue("azurekv_secret.test", tfjsonpath.New("previous_version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_expiresIn(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	expirationDatesDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: expiresInResourceConfig(rn, 1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					expirationDatesDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("expiration_date")),
+				},
+			},
+			// A new version gets a new expiration date
+			{
+				Config: expiresInResourceConfig(rn, 2),
+				ConfigStateChecks: []statecheck.StateCheck{
+					expirationDatesDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("expiration_date")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_rollingExpirationDays(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	expirationDatesDiffer := statecheck.CompareValue(compare.ValuesDiffer())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			// The expiration date is always within the threshold
+			{
+				Config: rollingExpirationDaysResourceConfig(rn),
+				ConfigStateChecks: []statecheck.StateCheck{
+					expirationDatesDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("expiration_date")),
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			// Extend the expiration date without creating a new version
+			{
+				Config: rollingExpirationDaysResourceConfig(rn),
+				ConfigStateChecks: []statecheck.StateCheck{
+					expirationDatesDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("expiration_date")),
+				},
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_trackValueChecksum(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: trackValueChecksumResourceConfig(rn),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"azurekv_secret.test",
+						tfjsonpath.New("value_checksum"),
+						// SHA-256 of "secret-value"
+						knownvalue.StringExact("31160254d1297393d2ad00e1c01851aec834361e02c524b89fe06aff2879ce6a"),
+					),
+					statecheck.ExpectKnownValue(
+						"azurekv_secret.test",
+						tfjsonpath.New("tags"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"environment": knownvalue.StringExact("test"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_inferContentType(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: inferContentTypeResourceConfig(rn),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"azurekv_secret.test",
+						tfjsonpath.New("content_type"),
+						knownvalue.StringExact("application/json"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_moveFromAzapiResource(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+			"azapi": {
+				Source: "azure/azapi",
+			},
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: azapiSecretResourceConfig(rn),
+			},
+			{
+				Config: movedFromAzapiResourceConfig(rn),
+				Check:  resource.TestCheckResourceAttr("azurekv_secret.test", "name", "secret-name-"+rn),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_trackLatestVersion(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+	versionsSame := statecheck.CompareValue(compare.ValuesSame())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: trackLatestVersionResourceConfig(rn, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsSame.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+			// Add a new version outside of azurekv_secret
+			{
+				Config: trackLatestVersionResourceConfig(rn, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					versionsSame.AddStateValue("azurekv_secret.test", tfjsonpath.New("version")),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_importByIdentityWithVersion(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: importByIdentityWithVersionResourceConfig(rn, false),
+			},
+			{
+				Config: importByIdentityWithVersionResourceConfig(rn, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"azurekv_secret.test", tfjsonpath.New("pinned_version"),
+						"azurerm_key_vault_secret.test", tfjsonpath.New("version"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectIdentityValue("azurekv_secret.test", tfjsonpath.New("version"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_overwriteExisting(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      existingSecretResourceConfig(rn, false),
+				ExpectError: regexp.MustCompile("Secret Already Exists"),
+			},
+			{
+				Config: existingSecretResourceConfig(rn, true),
+				Check:  resource.TestCheckResourceAttrPair("azurekv_secret.test", "name", "azurerm_key_vault_secret.existing", "name"),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_purgeSoftDeleteOnDestroy(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: purgeSoftDeleteOnDestroyResourceConfig(rn),
+			},
+			// Destroy the secret
+			{
+				Config: providersConfig(rn),
+			},
+			// Recreating the secret fails unless the deleted secret has been purged
+			{
+				Config: purgeSoftDeleteOnDestroyResourceConfig(rn),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_includeVersions(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: includeVersionsResourceConfig(rn, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurekv_secret.test", "versions.#", "1"),
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "versions.0.version", "azurekv_secret.test", "version"),
+				),
+			},
+			// The new version is listed first
+			{
+				Config: includeVersionsResourceConfig(rn, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("azurekv_secret.test", "versions.#", "2"),
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "versions.0.version", "azurekv_secret.test", "version"),
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "versions.1.version", "azurekv_secret.test", "previous_version"),
+				),
 			},
 		},
 	})
 }
 
-func TestAccSecretResource_complete(t *testing.T) {
+func TestAccSecretResource_stableID(t *testing.T) {
 	t.Parallel()
 
 	rn := generateRandomName(23)
+	idsSame := statecheck.CompareValue(compare.ValuesSame())
+	versionedIDsDiffer := statecheck.CompareValue(compare.ValuesDiffer())
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -55,22 +630,36 @@ func TestAccSecretResource_complete(t *testing.T) {
 			},
 		},
 		Steps: []resource.TestStep{
-			buildTestStep(completeResourceConfig(rn, 1)),
 			{
-				ResourceName:      "azurekv_secret.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config: stableIDResourceConfig(rn, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "id", "azurekv_secret.test", "versionless_id"),
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "versioned_id", "data.azurerm_key_vault_secret.test", "id"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					idsSame.AddStateValue("azurekv_secret.test", tfjsonpath.New("id")),
+					versionedIDsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("versioned_id")),
+				},
 			},
+			// A new version doesn't change id
 			{
-				ResourceName:    "azurekv_secret.test",
-				ImportState:     true,
-				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				Config: stableIDResourceConfig(rn, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("azurekv_secret.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					idsSame.AddStateValue("azurekv_secret.test", tfjsonpath.New("id")),
+					versionedIDsDiffer.AddStateValue("azurekv_secret.test", tfjsonpath.New("versioned_id")),
+				},
+				Check: resource.TestCheckResourceAttrPair("azurekv_secret.test", "versioned_id", "data.azurerm_key_vault_secret.test", "id"),
 			},
 		},
 	})
 }
 
-func TestAccSecretResource_update(t *testing.T) {
+func TestAccSecretResource_vaultName(t *testing.T) {
 	t.Parallel()
 
 	rn := generateRandomName(23)
@@ -85,20 +674,65 @@ func TestAccSecretResource_update(t *testing.T) {
 			},
 		},
 		Steps: []resource.TestStep{
-			buildTestStep(basicResourceConfig(rn, 1)),
 			{
-				ResourceName:      "azurekv_secret.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config: vaultNameResourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "key_vault_id", "data.azurekv_secret.test", "key_vault_id"),
+					resource.TestCheckResourceAttrPair("azurekv_secret.test", "version", "data.azurekv_secret.test", "version"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_deleteBehaviorAbandon(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: deleteBehaviorResourceConfig(rn, "abandon", false),
+			},
+			// Remove the secret from the state
+			{
+				Config: providersConfig(rn),
+			},
+			// The abandoned secret still exists
+			{
+				Config:      deleteBehaviorResourceConfig(rn, "delete", false),
+				ExpectError: regexp.MustCompile("Secret Already Exists"),
+			},
+			// Adopt the abandoned secret to delete it
+			{
+				Config: deleteBehaviorResourceConfig(rn, "delete", true),
 			},
-			// Update the secret value
-			buildTestStep(basicResourceConfig(rn, 2)),
-			// Update the properties
-			buildTestStep(completeResourceConfig(rn, 2)),
 		},
 	})
 }
 
+// The attributes about the value are unknown to imported resources.
+var importStateVerifyIgnore = []string{"value_length", "value_entropy_class"}
+
+func importStateIDFromAttribute(resourceName, key string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return rs.Primary.Attributes[key], nil
+	}
+}
+
 func buildTestStep(config string) resource.TestStep {
 	return resource.TestStep{
 		Config: config,
@@ -121,6 +755,11 @@ func buildTestStep(config string) resource.TestStep {
 				tfjsonpath.New("value_wo"),
 				knownvalue.Null(),
 			),
+			statecheck.ExpectKnownValue(
+				"azurekv_secret.test",
+				tfjsonpath.New("value_length"),
+				knownvalue.Int64Exact(int64(len("secret-value"))),
+			),
 		},
 	}
 }
@@ -167,3 +806,334 @@ data "azurerm_key_vault_secret" "test" {
 }
 `, providersConfig(resourceSuffix), resourceSuffix, version)
 }
+
+func expiresInResourceConfig(resourceSuffix string, version int) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = %d
+
+  expires_in = "2160h"
+}
+`, providersConfig(resourceSuffix), resourceSuffix, version)
+}
+
+func rollingExpirationDaysResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  expires_in              = "24h"
+  rolling_expiration_days = 2
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func trackValueChecksumResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  track_value_checksum = true
+
+  tags = {
+    environment = "test"
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func inferContentTypeResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = jsonencode({ username = "admin", password = "secret-value" })
+  value_wo_version = 1
+
+  infer_content_type = true
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func azapiSecretResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.KeyVault/vaults/secrets@2023-07-01"
+  name      = "secret-name-%s"
+  parent_id = local.key_vault_id
+  body = {
+    properties = {
+      value = "secret-value"
+    }
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func movedFromAzapiResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+moved {
+  from = azapi_resource.test
+  to   = azurekv_secret.test
+}
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func trackLatestVersionResourceConfig(resourceSuffix string, addVersion bool) string {
+	config := fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  track_latest_version = false
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+
+	if addVersion {
+		config += `
+action "azurekv_sync_secret" "test" {
+  config {
+    name                     = azurekv_secret.test.name
+    source_key_vault_id      = azurekv_secret.test.key_vault_id
+    destination_key_vault_id = azurekv_secret.test.key_vault_id
+  }
+}
+
+resource "terraform_data" "sync" {
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.azurekv_sync_secret.test]
+    }
+  }
+}
+`
+	}
+
+	return config
+}
+
+func existingSecretResourceConfig(resourceSuffix string, overwriteExisting bool) string {
+	return fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "existing" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = "secret-value"
+}
+
+resource "azurekv_secret" "test" {
+  name         = azurerm_key_vault_secret.existing.name
+  key_vault_id = azurerm_key_vault_secret.existing.key_vault_id
+
+  value_wo           = "secret-value"
+  value_wo_version   = 1
+  overwrite_existing = %t
+}
+`, providersConfig(resourceSuffix), resourceSuffix, overwriteExisting)
+}
+
+func purgeSoftDeleteOnDestroyResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  purge_soft_delete_on_destroy = true
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func valueWOTriggerResourceConfig(resourceSuffix, trigger string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_trigger = %q
+}
+`, providersConfig(resourceSuffix), resourceSuffix, trigger)
+}
+
+func valueWOVersionStringResourceConfig(resourceSuffix, version string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = %q
+}
+`, providersConfig(resourceSuffix), resourceSuffix, version)
+}
+
+func valueResourceConfig(resourceSuffix, value string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = %q
+}
+`, providersConfig(resourceSuffix), resourceSuffix, value)
+}
+
+func triggersResourceConfig(resourceSuffix, appVersion string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo = "secret-value"
+  triggers = {
+    app_version = %q
+    rotated_on  = "2025-01-23"
+  }
+}
+`, providersConfig(resourceSuffix), resourceSuffix, appVersion)
+}
+
+func deleteBehaviorResourceConfig(resourceSuffix, deleteBehavior string, overwriteExisting bool) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  delete_behavior    = %q
+  overwrite_existing = %t
+}
+`, providersConfig(resourceSuffix), resourceSuffix, deleteBehavior, overwriteExisting)
+}
+
+func vaultNameResourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+locals {
+  vault_name = element(split("/", local.key_vault_id), 8)
+}
+
+resource "azurekv_secret" "test" {
+  name       = "secret-name-%s"
+  vault_name = local.vault_name
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+}
+
+data "azurekv_secret" "test" {
+  name      = azurekv_secret.test.name
+  vault_uri = "https://${local.vault_name}.vault.azure.net/"
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+}
+
+func includeVersionsResourceConfig(resourceSuffix string, version int) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = %d
+
+  include_versions = true
+}
+`, providersConfig(resourceSuffix), resourceSuffix, version)
+}
+
+func importByIdentityWithVersionResourceConfig(resourceSuffix string, imported bool) string {
+	config := fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+  value        = "secret-value"
+}
+`, providersConfig(resourceSuffix), resourceSuffix)
+	if !imported {
+		return config
+	}
+
+	return config + `
+import {
+  to = azurekv_secret.test
+  identity = {
+    name         = azurerm_key_vault_secret.test.name
+    key_vault_id = azurerm_key_vault_secret.test.key_vault_id
+    version      = azurerm_key_vault_secret.test.version
+  }
+}
+
+resource "azurekv_secret" "test" {
+  name            = azurerm_key_vault_secret.test.name
+  key_vault_id    = azurerm_key_vault_secret.test.key_vault_id
+  pinned_version  = azurerm_key_vault_secret.test.version
+  delete_behavior = "abandon"
+}
+`
+}
+
+func stableIDResourceConfig(resourceSuffix string, version int) string {
+	return fmt.Sprintf(`%s
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-%s"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value-%d"
+  value_wo_version = %d
+
+  stable_id = true
+}
+
+data "azurerm_key_vault_secret" "test" {
+  name         = azurekv_secret.test.name
+  key_vault_id = azurekv_secret.test.key_vault_id
+  version      = azurekv_secret.test.version
+}
+`, providersConfig(resourceSuffix), resourceSuffix, version, version)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// azurermKeyVaultSecretState is the state of azurerm_key_vault_secret written by the azurerm provider
+const azurermKeyVaultSecretState = `{
+  "content_type": "text/plain",
+  "expiration_date": "2030-01-01T00:00:00Z",
+  "id": "https://vault-name.vault.azure.net/secrets/secret-name/00000000000000000000000000000001",
+  "key_vault_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name",
+  "name": "secret-name",
+  "not_before_date": "",
+  "resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name/secrets/secret-name/versions/00000000000000000000000000000001",
+  "resource_versionless_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name/secrets/secret-name",
+  "tags": {
+    "env": "test"
+  },
+  "timeouts": null,
+  "value": "secret-value",
+  "value_wo": null,
+  "value_wo_version": null,
+  "version": "00000000000000000000000000000001",
+  "versionless_id": "https://vault-name.vault.azure.net/secrets/secret-name"
+}`
+
+func TestMoveSecretStateFromAzurermKeyVaultSecret(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typeName := range []string{"azurekv_key_vault_secret", "azurekv_secret"} {
+		t.Run(typeName, func(t *testing.T) {
+			t.Parallel()
+
+			moveResp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/hashicorp/azurerm",
+				SourceTypeName:        "azurerm_key_vault_secret",
+				SourceSchemaVersion:   0,
+				SourceState:           &tfprotov6.RawState{JSON: []byte(azurermKeyVaultSecretState)},
+				TargetTypeName:        typeName,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertNoDiagnostics(t, moveResp.Diagnostics)
+
+			resourceType := schemaResp.ResourceSchemas[typeName].ValueType().(tftypes.Object)
+			moved, err := moveResp.TargetState.Unmarshal(resourceType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var attrs map[string]tftypes.Value
+			if err := moved.As(&attrs); err != nil {
+				t.Fatal(err)
+			}
+
+			for name, want := range map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "https://vault-name.vault.azure.net/secrets/secret-name/00000000000000000000000000000001"),
+				"versionless_id":  tftypes.NewValue(tftypes.String, "https://vault-name.vault.azure.net/secrets/secret-name"),
+				"version":         tftypes.NewValue(tftypes.String, "00000000000000000000000000000001"),
+				"name":            tftypes.NewValue(tftypes.String, "secret-name"),
+				"key_vault_id":    tftypes.NewValue(tftypes.String, "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name"),
+				"content_type":    tftypes.NewValue(tftypes.String, "text/plain"),
+				"not_before_date": tftypes.NewValue(tftypes.String, nil),
+				"expiration_date": tftypes.NewValue(tftypes.String, "2030-01-01T00:00:00Z"),
+				"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"env": tftypes.NewValue(tftypes.String, "test"),
+				}),
+				"value":           tftypes.NewValue(tftypes.String, nil),
+				"delete_behavior": tftypes.NewValue(tftypes.String, deleteBehaviorDelete),
+				"stable_id":       tftypes.NewValue(tftypes.Bool, false),
+			} {
+				if !attrs[name].Equal(want) {
+					t.Errorf("%s = %v, want %v", name, attrs[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveSecretStateFromUnsupportedAzapiResource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typeName := range []string{"azurekv_key_vault_secret", "azurekv_secret"} {
+		t.Run(typeName, func(t *testing.T) {
+			t.Parallel()
+
+			moveResp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/azure/azapi",
+				SourceTypeName:        "azapi_resource",
+				SourceSchemaVersion:   2,
+				SourceState:           &tfprotov6.RawState{JSON: []byte(`{"id":"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name/keys/key-name","type":"Microsoft.KeyVault/vaults/keys@2023-07-01"}`)},
+				TargetTypeName:        typeName,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(moveResp.Diagnostics) != 1 {
+				t.Fatalf("got %d diagnostics, want 1: %v", len(moveResp.Diagnostics), moveResp.Diagnostics)
+			}
+			want := `Only azapi_resource of the type Microsoft.KeyVault/vaults/secrets can be moved to this resource, got: "Microsoft.KeyVault/vaults/keys@2023-07-01"`
+			if got := moveResp.Diagnostics[0].Detail; got != want {
+				t.Errorf("got the detail %q, want %q", got, want)
+			}
+		})
+	}
+}
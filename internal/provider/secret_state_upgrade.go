@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func (r *SecretResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := secretSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeSecretStateFromV0,
+		},
+	}
+}
+
+// secretSchemaV0 returns the schema of azurekv_secret before value_wo_version accepted a string.
+// Only the types of the attributes matter to read the prior state.
+func secretSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"key_vault_id": schema.StringAttribute{
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"versionless_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"value_wo": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"value_wo_version": schema.Int32Attribute{
+				Required: true,
+			},
+			"content_type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+			"not_before_date": schema.StringAttribute{
+				Optional:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+			"expiration_date": schema.StringAttribute{
+				Optional:   true,
+				CustomType: timetypes.RFC3339Type{},
+			},
+			"version": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_versionless_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+		},
+	}
+}
+
+// upgradeSecretStateFromV0 converts value_wo_version from an integer to a dynamic value holding the integer,
+// and sets the attributes added after the version 0 to their defaults so that the upgrade doesn't plan any change.
+func upgradeSecretStateFromV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.State.Raw.As(&attrs); err != nil {
+		resp.Diagnostics.AddError("Failed to Upgrade State", "An unexpected error occurred while reading the prior state: "+err.Error())
+		return
+	}
+
+	if attrs["value_wo_version"].IsNull() {
+		attrs["value_wo_version"] = tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+	}
+
+	// The version 0 always uses the versioned ID as id and doesn't support default_tags
+	attrs["versioned_id"] = attrs["id"]
+	attrs["tags_all"] = attrs["tags"]
+
+	typ := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	for k, attrType := range typ.AttributeTypes {
+		if _, ok := attrs[k]; ok {
+			continue
+		}
+		v, err := defaultTerraformValue(ctx, resp.State.Schema.GetAttributes()[k])
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to Upgrade State", "An unexpected error occurred while setting the default value of "+k+": "+err.Error())
+			return
+		}
+		if v == nil {
+			attrs[k] = tftypes.NewValue(attrType, nil)
+		} else {
+			attrs[k] = *v
+		}
+	}
+
+	resp.State.Raw = tftypes.NewValue(typ, attrs)
+}
+
+// defaultTerraformValue returns the default value of the attribute, or nil if it has no default.
+func defaultTerraformValue(ctx context.Context, a schema.Attribute) (*tftypes.Value, error) {
+	var v attr.Value
+	switch a := a.(type) {
+	case schema.BoolAttribute:
+		if a.Default == nil {
+			return nil, nil
+		}
+		var resp defaults.BoolResponse
+		a.Default.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+		v = resp.PlanValue
+	case schema.StringAttribute:
+		if a.Default == nil {
+			return nil, nil
+		}
+		var resp defaults.StringResponse
+		a.Default.DefaultString(ctx, defaults.StringRequest{}, &resp)
+		v = resp.PlanValue
+	case schema.MapAttribute:
+		if a.Default == nil {
+			return nil, nil
+		}
+		var resp defaults.MapResponse
+		a.Default.DefaultMap(ctx, defaults.MapRequest{}, &resp)
+		v = resp.PlanValue
+	default:
+		return nil, nil
+	}
+
+	tv, err := v.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tv, nil
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// secretStateV0 is the state of azurekv_secret written by the provider with the schema version 0
+const secretStateV0 = `{
+  "content_type": "",
+  "expiration_date": null,
+  "id": "https://vault-name.vault.azure.net/secrets/secret-name/00000000000000000000000000000001",
+  "key_vault_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name",
+  "name": "secret-name",
+  "not_before_date": null,
+  "resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name/secrets/secret-name/versions/00000000000000000000000000000001",
+  "resource_versionless_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/vault-name/secrets/secret-name",
+  "tags": {
+    "env": "test"
+  },
+  "value_wo": null,
+  "value_wo_version": 3,
+  "version": "00000000000000000000000000000001",
+  "versionless_id": "https://vault-name.vault.azure.net/secrets/secret-name"
+}`
+
+func TestUpgradeSecretStateFromV0(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := nullObjectValue(providerType, map[string]tftypes.Value{
+		"mock_mode": tftypes.NewValue(tftypes.Bool, true),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: newTestDynamicValue(t, providerType, providerConfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, configureResp.Diagnostics)
+
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "azurekv_secret",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(secretStateV0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, upgradeResp.Diagnostics)
+
+	resourceType := schemaResp.ResourceSchemas["azurekv_secret"].ValueType().(tftypes.Object)
+	upgraded, err := upgradeResp.UpgradedState.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := upgraded.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	tags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"env": tftypes.NewValue(tftypes.String, "test"),
+	})
+	for name, want := range map[string]tftypes.Value{
+		"name":                    tftypes.NewValue(tftypes.String, "secret-name"),
+		"value_wo_version":        tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+		"tags":                    tags,
+		"tags_all":                tags,
+		"content_type":            tftypes.NewValue(tftypes.String, ""),
+		"delete_behavior":         tftypes.NewValue(tftypes.String, deleteBehaviorDelete),
+		"overwrite_existing":      tftypes.NewValue(tftypes.Bool, false),
+		"track_latest_version":    tftypes.NewValue(tftypes.Bool, true),
+		"stable_id":               tftypes.NewValue(tftypes.Bool, false),
+		"include_versions":        tftypes.NewValue(tftypes.Bool, false),
+		"track_value_checksum":    tftypes.NewValue(tftypes.Bool, false),
+		"detect_external_changes": tftypes.NewValue(tftypes.Bool, false),
+		"infer_content_type":      tftypes.NewValue(tftypes.Bool, false),
+		"value_wo_trigger":        tftypes.NewValue(tftypes.String, nil),
+	} {
+		if !attrs[name].Equal(want) {
+			t.Errorf("%s = %v, want %v", name, attrs[name], want)
+		}
+	}
+
+	// The configuration written for the version 0 must plan no change
+	config := nullObjectValue(resourceType, map[string]tftypes.Value{
+		"name":             attrs["name"],
+		"key_vault_id":     attrs["key_vault_id"],
+		"value_wo":         tftypes.NewValue(tftypes.String, "secret-value"),
+		"value_wo_version": tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+		"tags":             tags,
+	})
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "azurekv_secret",
+		PriorState:       upgradeResp.UpgradedState,
+		ProposedNewState: newTestDynamicValue(t, resourceType, proposedNewState(resourceType, schemaResp.ResourceSchemas["azurekv_secret"], upgraded, config)),
+		Config:           newTestDynamicValue(t, resourceType, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDiagnostics(t, planResp.Diagnostics)
+
+	planned, err := planResp.PlannedState.Unmarshal(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := upgraded.Diff(planned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range diffs {
+		t.Errorf("unexpected diff at %s: %v -> %v", d.Path, d.Value1, d.Value2)
+	}
+	if len(planResp.RequiresReplace) > 0 {
+		t.Errorf("RequiresReplace = %v, want none", planResp.RequiresReplace)
+	}
+}
+
+// nullObjectValue returns the object with the attributes, leaving the others null.
+func nullObjectValue(typ tftypes.Object, attrs map[string]tftypes.Value) tftypes.Value {
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+// proposedNewState mimics how Terraform proposes the new state:
+// the configured values, and the prior values of the computed attributes that are not configured.
+func proposedNewState(typ tftypes.Object, s *tfprotov6.Schema, prior, config tftypes.Value) tftypes.Value {
+	var priorAttrs, configAttrs map[string]tftypes.Value
+	_ = prior.As(&priorAttrs)
+	_ = config.As(&configAttrs)
+
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for _, a := range s.Block.Attributes {
+		if a.Computed && configAttrs[a.Name].IsNull() {
+			values[a.Name] = priorAttrs[a.Name]
+		} else {
+			values[a.Name] = configAttrs[a.Name]
+		}
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+func newTestDynamicValue(t *testing.T, typ tftypes.Type, v tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(typ, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func assertNoDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
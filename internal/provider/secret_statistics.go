@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// secretStatistics is the summary of the secrets in a key vault.
+type secretStatistics struct {
+	TotalCount int64
+	// CountByContentType excludes the secrets without content types.
+	CountByContentType map[string]int64
+	// CountByTag is the number of the secrets having each tag regardless of the values.
+	CountByTag    map[string]int64
+	ExpiringCount int64
+	// NearestExpiration is the earliest expiration date not before now, or nil if no secrets expire in the future.
+	NearestExpiration *time.Time
+}
+
+// newSecretStatistics returns the statistics of no secrets.
+func newSecretStatistics() secretStatistics {
+	return secretStatistics{
+		CountByContentType: map[string]int64{},
+		CountByTag:         map[string]int64{},
+	}
+}
+
+// add counts the secret, in which the secrets expiring in [now, now+expiringWithin) are counted as expiring.
+// The secrets are counted one by one so that the secrets in a large key vault don't have to be kept in memory.
+func (s *secretStatistics) add(secret *azsecrets.SecretProperties, now time.Time, expiringWithin time.Duration) {
+	s.TotalCount++
+	if secret.ContentType != nil && *secret.ContentType != "" {
+		s.CountByContentType[*secret.ContentType]++
+	}
+	for k := range secret.Tags {
+		s.CountByTag[k]++
+	}
+
+	if secret.Attributes == nil || secret.Attributes.Expires == nil || secret.Attributes.Expires.Before(now) {
+		return
+	}
+	expires := *secret.Attributes.Expires
+	if expires.Before(now.Add(expiringWithin)) {
+		s.ExpiringCount++
+	}
+	if s.NearestExpiration == nil || expires.Before(*s.NearestExpiration) {
+		s.NearestExpiration = &expires
+	}
+}
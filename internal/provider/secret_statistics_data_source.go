@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultExpiringWithinDays is the default of expiring_within_days.
+const defaultExpiringWithinDays = 30
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*SecretStatisticsDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*SecretStatisticsDataSource)(nil)
+
+func NewSecretStatisticsDataSource() datasource.DataSource {
+	return &SecretStatisticsDataSource{}
+}
+
+// SecretStatisticsDataSource defines the data source implementation.
+type SecretStatisticsDataSource struct {
+	client            Client
+	defaultKeyVaultID string
+}
+
+type SecretStatisticsDataSourceModel struct {
+	secretFilterModel
+	KeyVaultID            types.String      `tfsdk:"key_vault_id"`
+	ExpiringWithinDays    types.Int64       `tfsdk:"expiring_within_days"`
+	PageSize              types.Int32       `tfsdk:"page_size"`
+	MaxSecrets            types.Int64       `tfsdk:"max_secrets"`
+	ID                    types.String      `tfsdk:"id"`
+	TotalCount            types.Int64       `tfsdk:"total_count"`
+	CountByContentType    types.Map         `tfsdk:"count_by_content_type"`
+	CountByTag            types.Map         `tfsdk:"count_by_tag"`
+	ExpiringCount         types.Int64       `tfsdk:"expiring_count"`
+	NearestExpirationDate timetypes.RFC3339 `tfsdk:"nearest_expiration_date"`
+	Truncated             types.Bool        `tfsdk:"truncated"`
+}
+
+func (d *SecretStatisticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_statistics"
+}
+
+func (d *SecretStatisticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to summarize the Key Vault Secrets in a Key Vault for dashboards and policy gates " +
+			"without exporting the list of the secrets. The summary is based on the current versions of the secrets.",
+
+		Attributes: map[string]schema.Attribute{
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault to summarize, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"If not specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"expiring_within_days": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Specifies the number of days within which the secrets are counted in `expiring_count`. Defaults to `%d`.", defaultExpiringWithinDays),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Specifies the prefix of the names of the Key Vault Secrets to summarize.",
+				Optional:            true,
+			},
+			"name_glob": schema.StringAttribute{
+				MarkdownDescription: "Specifies the glob pattern, such as `app-*-password`, that the names of the Key Vault Secrets to summarize must match.",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Specifies the regular expression that the names of the Key Vault Secrets to summarize must match.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Specifies the tags that the Key Vault Secrets to summarize must have with the same values.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"page_size": schema.Int32Attribute{
+				MarkdownDescription: fmt.Sprintf("Specifies the number of Key Vault Secrets requested in a page, up to `%d`. Smaller pages keep each request short in large Key Vaults.", maxListPageSize),
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.Between(1, maxListPageSize),
+				},
+			},
+			"max_secrets": schema.Int64Attribute{
+				MarkdownDescription: "Specifies the maximum number of Key Vault Secrets to summarize. Listing stops once this number of matching secrets are read, " +
+					"so the data source stays within the time budget in Key Vaults with thousands of secrets. If not specified, all the secrets are summarized.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault.",
+				Computed:            true,
+			},
+			"total_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of the Key Vault Secrets.",
+				Computed:            true,
+			},
+			"count_by_content_type": schema.MapAttribute{
+				MarkdownDescription: "The number of the Key Vault Secrets by content type. The secrets without content types are not counted.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"count_by_tag": schema.MapAttribute{
+				MarkdownDescription: "The number of the Key Vault Secrets having each tag regardless of the values.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"expiring_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of the Key Vault Secrets that expire within `expiring_within_days` days. The secrets that have already expired are not counted.",
+				Computed:            true,
+			},
+			"nearest_expiration_date": schema.StringAttribute{
+				MarkdownDescription: "The earliest date and time at which any of the Key Vault Secrets expires, excluding the secrets that have already expired.",
+				Computed:            true,
+				CustomType:          timetypes.RFC3339Type{},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether the summary excludes some Key Vault Secrets because of `max_secrets`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SecretStatisticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
+}
+
+func (d *SecretStatisticsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model SecretStatisticsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyVaultID.IsNull() {
+		if d.defaultKeyVaultID == "" {
+			resp.Diagnostics.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		model.KeyVaultID = types.StringValue(d.defaultKeyVaultID)
+	}
+	if model.ExpiringWithinDays.IsNull() {
+		model.ExpiringWithinDays = types.Int64Value(defaultExpiringWithinDays)
+	}
+	model.ID = model.KeyVaultID
+
+	filter, diags := newSecretFilter(ctx, model.secretFilterModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	expiringWithin := time.Duration(model.ExpiringWithinDays.ValueInt64()) * 24 * time.Hour
+	stats := newSecretStatistics()
+	model.Truncated = types.BoolValue(false)
+	options := &ListSecretPropertiesOptions{PageSize: model.PageSize.ValueInt32()}
+	for secret, err := range d.client.ListSecretProperties(ctx, model.KeyVaultID.ValueString(), options) {
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to List Secrets", err.Error()+azureErrorHint(err, dataActionReadMetadata))
+			return
+		}
+		if !filter.match(secret) {
+			continue
+		}
+		if !model.MaxSecrets.IsNull() && stats.TotalCount >= model.MaxSecrets.ValueInt64() {
+			model.Truncated = types.BoolValue(true)
+			break
+		}
+		stats.add(secret, now, expiringWithin)
+	}
+
+	model.TotalCount = types.Int64Value(stats.TotalCount)
+	model.ExpiringCount = types.Int64Value(stats.ExpiringCount)
+	model.NearestExpirationDate = timetypes.NewRFC3339Null()
+	if stats.NearestExpiration != nil {
+		model.NearestExpirationDate = timetypes.NewRFC3339TimeValue(stats.NearestExpiration.UTC())
+	}
+
+	model.CountByContentType, diags = types.MapValueFrom(ctx, types.Int64Type, stats.CountByContentType)
+	resp.Diagnostics.Append(diags...)
+	model.CountByTag, diags = types.MapValueFrom(ctx, types.Int64Type, stats.CountByTag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSecretStatisticsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: secretStatisticsDataSourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurekv_secret_statistics.test", "expiring_within_days", "30"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret_statistics.test", "total_count"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret_statistics.test", "count_by_content_type.password"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret_statistics.test", "count_by_tag.statistics-"+rn),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret_statistics.test", "expiring_count"),
+					resource.TestCheckResourceAttrSet("data.azurekv_secret_statistics.test", "nearest_expiration_date"),
+				),
+			},
+		},
+	})
+}
+
+func secretStatisticsDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name            = "secret-name-%s"
+  key_vault_id    = local.key_vault_id
+  value           = "secret-value"
+  content_type    = "password"
+  expiration_date = timeadd(plantimestamp(), "240h")
+
+  tags = {
+    "statistics-%s" = "true"
+  }
+
+  lifecycle {
+    ignore_changes = [expiration_date]
+  }
+}
+
+data "azurekv_secret_statistics" "test" {
+  key_vault_id = azurerm_key_vault_secret.test.key_vault_id
+}
+`, providersConfig(resourceSuffix), resourceSuffix, resourceSuffix)
+}
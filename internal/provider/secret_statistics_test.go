@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+func TestSecretStatisticsAdd(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	secret := func(contentType string, tags map[string]*string, expires int64) *azsecrets.SecretProperties {
+		props := secretProperties("version", 100)
+		if contentType != "" {
+			props.ContentType = to.Ptr(contentType)
+		}
+		props.Tags = tags
+		if expires != 0 {
+			props.Attributes.Expires = to.Ptr(time.Unix(expires, 0))
+		}
+		return props
+	}
+
+	got := newSecretStatistics()
+	for _, s := range []*azsecrets.SecretProperties{
+		secret("password", map[string]*string{"env": to.Ptr("prod"), "team": to.Ptr("a")}, 0),
+		secret("password", map[string]*string{"env": to.Ptr("dev")}, 500),
+		secret("application/json", nil, 1500),
+		secret("", nil, 1200),
+		secret("", nil, 5000),
+	} {
+		got.add(s, now, 1000*time.Second)
+	}
+	want := secretStatistics{
+		TotalCount:         5,
+		CountByContentType: map[string]int64{"password": 2, "application/json": 1},
+		CountByTag:         map[string]int64{"env": 2, "team": 1},
+		ExpiringCount:      2,
+		NearestExpiration:  to.Ptr(time.Unix(1200, 0)),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("secretStatistics = %+v, want %+v", got, want)
+	}
+}
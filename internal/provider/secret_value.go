@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const jsonContentType = "application/json"
+
+// hasValueSource returns whether any of the attributes specifying the value is configured.
+// The value of imported resources doesn't have to be managed.
+func (m SecretResourceModel) hasValueSource() bool {
+	return !m.Value.IsNull() || !m.ValueWO.IsNull() || !m.ValueJSONWO.IsNull() || !m.ValueSourceEnv.IsNull() || !m.ValueSourceCommand.IsNull()
+}
+
+// secretValueFromConfig returns the secret value from value, value_wo, value_json_wo, or the source specified by value_source_env or value_source_command.
+func secretValueFromConfig(ctx context.Context, config SecretResourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !config.ValueSourceCommand.IsNull() {
+		var command []string
+		diags.Append(config.ValueSourceCommand.ElementsAs(ctx, &command, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+
+		value, err := runValueSourceCommand(ctx, command)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("value_source_command"),
+				"Failed to Run Command",
+				fmt.Sprintf("The command specified by value_source_command failed: %s", err),
+			)
+		}
+		return value, diags
+	}
+
+	if !config.ValueSourceEnv.IsNull() {
+		name := config.ValueSourceEnv.ValueString()
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("value_source_env"),
+				"Missing Environment Variable",
+				fmt.Sprintf("The environment variable %q specified by value_source_env is not set.", name),
+			)
+		}
+		return value, diags
+	}
+
+	if !config.Value.IsNull() {
+		return config.Value.ValueString(), diags
+	}
+
+	if !config.ValueJSONWO.IsNull() {
+		value, err := canonicalJSON(config.ValueJSONWO)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("value_json_wo"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("value_json_wo can't be serialized to JSON: %s", err),
+			)
+		}
+		return value, diags
+	}
+
+	return config.ValueWO.ValueString(), diags
+}
+
+// canonicalJSON serializes the value to JSON whose object keys are sorted, so that the same value always results in the same JSON.
+func canonicalJSON(value types.Dynamic) (string, error) {
+	v, err := toJSONValue(value.UnderlyingValue())
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// toJSONValue converts the value to the one encoding/json can marshal. Maps are marshaled with sorted keys.
+func toJSONValue(value attr.Value) (any, error) {
+	if value == nil || value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, errors.New("the value is unknown")
+	}
+
+	switch v := value.(type) {
+	case types.Dynamic:
+		return toJSONValue(v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Number:
+		f := v.ValueBigFloat()
+		if f.IsInt() {
+			i, _ := f.Int(nil)
+			return json.Number(i.String()), nil
+		}
+		return json.Number(f.Text('g', -1)), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Object:
+		return toJSONObject(v.Attributes())
+	case types.Map:
+		return toJSONObject(v.Elements())
+	case types.List:
+		return toJSONArray(v.Elements())
+	case types.Tuple:
+		return toJSONArray(v.Elements())
+	case types.Set:
+		return toJSONArray(v.Elements())
+	default:
+		return nil, fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+func toJSONObject(attrs map[string]attr.Value) (map[string]any, error) {
+	m := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		jsonValue, err := toJSONValue(v)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = jsonValue
+	}
+	return m, nil
+}
+
+func toJSONArray(elems []attr.Value) ([]any, error) {
+	a := make([]any, 0, len(elems))
+	for _, v := range elems {
+		jsonValue, err := toJSONValue(v)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, jsonValue)
+	}
+	return a, nil
+}
+
+// runValueSourceCommand runs the command and returns its stdout without trailing newlines.
+func runValueSourceCommand(ctx context.Context, command []string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// inferContentType returns the content type inferred from the shape of the value,
+// or an empty string if the value doesn't look like any known format.
+func inferContentType(value string) string {
+	trimmed := strings.TrimSpace(value)
+
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		if json.Valid([]byte(trimmed)) {
+			return jsonContentType
+		}
+	case strings.HasPrefix(trimmed, "-----BEGIN "):
+		return "application/x-pem-file"
+	default:
+		if der, err := base64.StdEncoding.DecodeString(trimmed); err == nil && isPKCS12(der) {
+			return "application/x-pkcs12"
+		}
+	}
+
+	return ""
+}
+
+// isPKCS12 reports whether the DER starts with a PFX structure, i.e. a SEQUENCE whose first element is INTEGER 3.
+func isPKCS12(der []byte) bool {
+	if len(der) < 2 || der[0] != 0x30 {
+		return false
+	}
+
+	// Skip the length of the SEQUENCE
+	offset := 2
+	if der[1]&0x80 != 0 {
+		offset += int(der[1] & 0x7f)
+	}
+
+	return bytes.HasPrefix(der[min(offset, len(der)):], []byte{0x02, 0x01, 0x03})
+}
+
+// setValueStats sets value_length and value_entropy_class of the value.
+func setValueStats(model *SecretResourceModel, value string) {
+	model.ValueLength = types.Int64Value(int64(utf8.RuneCountInString(value)))
+	model.ValueEntropyClass = types.StringValue(valueEntropyClass(value))
+}
+
+// valueEntropyClass estimates the entropy of the value from its length and the size of the character classes it uses.
+// The estimate assumes that the characters are chosen randomly, so it is an upper bound.
+func valueEntropyClass(value string) string {
+	var lower, upper, digit, other bool
+	for _, r := range value {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	poolSize := 0
+	if lower {
+		poolSize += 26
+	}
+	if upper {
+		poolSize += 26
+	}
+	if digit {
+		poolSize += 10
+	}
+	if other {
+		// The printable ASCII symbols and the space
+		poolSize += 33
+	}
+
+	bits := 0.0
+	if poolSize > 0 {
+		bits = float64(utf8.RuneCountInString(value)) * math.Log2(float64(poolSize))
+	}
+
+	switch {
+	case bits < 64:
+		return "low"
+	case bits < 128:
+		return "medium"
+	default:
+		return "high"
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// valueChecksumTagName is the tag storing the SHA-256 checksum of the secret value when track_value_checksum is true.
+	valueChecksumTagName = "azurekv-value-sha256"
+)
+
+func valueChecksum(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// popValueChecksumTag moves the checksum tag from tags to value_checksum if track_value_checksum is true.
+func popValueChecksumTag(ctx context.Context, model *SecretResourceModel) diag.Diagnostics {
+	if !model.TrackValueChecksum.ValueBool() {
+		model.ValueChecksum = types.StringNull()
+		return nil
+	}
+
+	tags, diags := toMap(model.Tags)
+	if diags.HasError() {
+		return diags
+	}
+
+	model.ValueChecksum = types.StringPointerValue(tags[valueChecksumTagName])
+	delete(tags, valueChecksumTagName)
+
+	model.Tags, diags = types.MapValueFrom(ctx, types.StringType, tags)
+	return diags
+}
+
+// valueChecksumDrifted reports whether the checksum tag of the current version differs from the checksum of the value
+// written by this provider, i.e. the secret value is changed outside of Terraform.
+func valueChecksumDrifted(model, state SecretResourceModel, writtenChecksum string) bool {
+	return model.TrackValueChecksum.ValueBool() && writtenChecksum != "" && state.ValueChecksum.ValueString() != writtenChecksum
+}
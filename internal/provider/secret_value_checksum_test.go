@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPopValueChecksumTag(t *testing.T) {
+	t.Parallel()
+
+	checksum := valueChecksum("secret-value")
+
+	tests := []struct {
+		name              string
+		track             bool
+		tags              map[string]attr.Value
+		wantTags          map[string]attr.Value
+		wantValueChecksum types.String
+	}{
+		{
+			name:  "tracked",
+			track: true,
+			tags: map[string]attr.Value{
+				"environment":        types.StringValue("test"),
+				valueChecksumTagName: types.StringValue(checksum),
+			},
+			wantTags: map[string]attr.Value{
+				"environment": types.StringValue("test"),
+			},
+			wantValueChecksum: types.StringValue(checksum),
+		},
+		{
+			name:  "tracked but missing",
+			track: true,
+			tags: map[string]attr.Value{
+				"environment": types.StringValue("test"),
+			},
+			wantTags: map[string]attr.Value{
+				"environment": types.StringValue("test"),
+			},
+			wantValueChecksum: types.StringNull(),
+		},
+		{
+			name:  "not tracked",
+			track: false,
+			tags: map[string]attr.Value{
+				valueChecksumTagName: types.StringValue(checksum),
+			},
+			wantTags: map[string]attr.Value{
+				valueChecksumTagName: types.StringValue(checksum),
+			},
+			wantValueChecksum: types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := SecretResourceModel{
+				TrackValueChecksum: types.BoolValue(tt.track),
+			}
+			model.Tags = types.MapValueMust(types.StringType, tt.tags)
+
+			if diags := popValueChecksumTag(t.Context(), &model); diags.HasError() {
+				t.Fatalf("popValueChecksumTag() diags = %v", diags)
+			}
+
+			if want := types.MapValueMust(types.StringType, tt.wantTags); !model.Tags.Equal(want) {
+				t.Errorf("tags = %v, want %v", model.Tags, want)
+			}
+			if !model.ValueChecksum.Equal(tt.wantValueChecksum) {
+				t.Errorf("value_checksum = %v, want %v", model.ValueChecksum, tt.wantValueChecksum)
+			}
+		})
+	}
+}
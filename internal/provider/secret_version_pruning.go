@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// pruneSecretVersions disables the enabled versions of the secret other than the newest maxVersions versions
+// and adds tags to them. It returns the disabled versions.
+func pruneSecretVersions(ctx context.Context, c Client, keyVaultID, name string, maxVersions int, tags map[string]*string) ([]string, error) {
+	versions, err := c.ListSecretPropertiesVersions(ctx, keyVaultID, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) <= maxVersions {
+		return nil, nil
+	}
+
+	slices.SortFunc(versions, func(a, b *azsecrets.SecretProperties) int {
+		return b.Attributes.Created.Compare(*a.Attributes.Created)
+	})
+
+	var disabled []string
+	for _, version := range versions[maxVersions:] {
+		if version.Attributes.Enabled != nil && !*version.Attributes.Enabled {
+			continue
+		}
+
+		versionTags := make(map[string]*string, len(version.Tags)+len(tags))
+		maps.Copy(versionTags, version.Tags)
+		maps.Copy(versionTags, tags)
+
+		_, err := c.UpdateSecretProperties(ctx, keyVaultID, name, version.ID.Version(), azsecrets.UpdateSecretPropertiesParameters{
+			SecretAttributes: &azsecrets.SecretAttributes{
+				Enabled: to.Ptr(false),
+			},
+			Tags: versionTags,
+		}, nil)
+		if err != nil {
+			return disabled, err
+		}
+		disabled = append(disabled, version.ID.Version())
+	}
+
+	return disabled, nil
+}
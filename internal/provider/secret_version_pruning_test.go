@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	azfake "github.com/Azure/azure-sdk-for-go/sdk/azcore/fake"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	azsecretsfake "github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets/fake"
+)
+
+func TestPruneSecretVersions(t *testing.T) {
+	t.Parallel()
+
+	disabledVersion := secretProperties("version-2", 200)
+	disabledVersion.Attributes.Enabled = to.Ptr(false)
+
+	taggedVersion := secretProperties("version-1", 100)
+	taggedVersion.Tags = map[string]*string{"owner": to.Ptr("team")}
+
+	tests := []struct {
+		name         string
+		versions     []*azsecrets.SecretProperties
+		maxVersions  int
+		tags         map[string]*string
+		wantDisabled []string
+		wantTags     []map[string]*string
+	}{
+		{
+			name: "fewer versions than the limit",
+			versions: []*azsecrets.SecretProperties{
+				secretProperties("version-1", 100),
+				secretProperties("version-2", 200),
+			},
+			maxVersions: 2,
+		},
+		{
+			name: "disable the oldest versions",
+			versions: []*azsecrets.SecretProperties{
+				secretProperties("version-3", 300),
+				secretProperties("version-1", 100),
+				secretProperties("version-4", 400),
+				secretProperties("version-2", 200),
+			},
+			maxVersions:  2,
+			wantDisabled: []string{"version-2", "version-1"},
+		},
+		{
+			name: "skip already disabled versions and merge tags",
+			versions: []*azsecrets.SecretProperties{
+				taggedVersion,
+				disabledVersion,
+				secretProperties("version-3", 300),
+			},
+			maxVersions:  1,
+			tags:         map[string]*string{"pruned": to.Ptr("true")},
+			wantDisabled: []string{"version-1"},
+			wantTags: []map[string]*string{
+				{"owner": to.Ptr("team"), "pruned": to.Ptr("true")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotTags []map[string]*string
+			fakeServer := azsecretsfake.Server{
+				NewListSecretPropertiesVersionsPager: func(
+					_ string,
+					_ *azsecrets.ListSecretPropertiesVersionsOptions,
+				) (resp azfake.PagerResponder[azsecrets.ListSecretPropertiesVersionsResponse]) {
+					resp.AddPage(http.StatusOK, azsecrets.ListSecretPropertiesVersionsResponse{
+						SecretPropertiesListResult: azsecrets.SecretPropertiesListResult{
+							Value: tt.versions,
+						},
+					}, nil)
+					return
+				},
+				UpdateSecretProperties: func(
+					_ context.Context,
+					_ string,
+					_ string,
+					parameters azsecrets.UpdateSecretPropertiesParameters,
+					_ *azsecrets.UpdateSecretPropertiesOptions,
+				) (resp azfake.Responder[azsecrets.UpdateSecretPropertiesResponse], errResp azfake.ErrorResponder) {
+					if parameters.SecretAttributes == nil || parameters.SecretAttributes.Enabled == nil || *parameters.SecretAttributes.Enabled {
+						t.Errorf("UpdateSecretProperties() doesn't disable the version")
+					}
+					gotTags = append(gotTags, parameters.Tags)
+					resp.SetResponse(http.StatusOK, azsecrets.UpdateSecretPropertiesResponse{}, nil)
+					return
+				},
+			}
+			c := newTestClient(t, &fakeServer)
+
+			got, err := pruneSecretVersions(t.Context(), c, testKeyVaultID, "secret-name", tt.maxVersions, tt.tags)
+			if err != nil {
+				t.Fatalf("pruneSecretVersions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantDisabled) {
+				t.Errorf("pruneSecretVersions() = %v, want %v", got, tt.wantDisabled)
+			}
+			if tt.wantTags != nil && !reflect.DeepEqual(gotTags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", gotTags, tt.wantTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SecretVersionModel describes a version of the secret in the versions attribute.
+type SecretVersionModel struct {
+	Version        types.String      `tfsdk:"version"`
+	ID             types.String      `tfsdk:"id"`
+	Enabled        types.Bool        `tfsdk:"enabled"`
+	CreatedDate    timetypes.RFC3339 `tfsdk:"created_date"`
+	ExpirationDate timetypes.RFC3339 `tfsdk:"expiration_date"`
+}
+
+var secretVersionAttrTypes = map[string]attr.Type{
+	"version":         types.StringType,
+	"id":              types.StringType,
+	"enabled":         types.BoolType,
+	"created_date":    timetypes.RFC3339Type{},
+	"expiration_date": timetypes.RFC3339Type{},
+}
+
+// newSecretVersions converts the versions of the secret to the models sorted from the newest to the oldest.
+func newSecretVersions(versions []*azsecrets.SecretProperties) []SecretVersionModel {
+	versions = slices.Clone(versions)
+	slices.SortFunc(versions, func(a, b *azsecrets.SecretProperties) int {
+		return b.Attributes.Created.Compare(*a.Attributes.Created)
+	})
+
+	models := make([]SecretVersionModel, 0, len(versions))
+	for _, version := range versions {
+		model := SecretVersionModel{
+			Version:        types.StringValue(version.ID.Version()),
+			ID:             types.StringValue(string(*version.ID)),
+			Enabled:        types.BoolPointerValue(version.Attributes.Enabled),
+			CreatedDate:    timetypes.NewRFC3339TimePointerValue(to.Ptr(version.Attributes.Created.UTC())),
+			ExpirationDate: timetypes.NewRFC3339Null(),
+		}
+		if version.Attributes.Expires != nil {
+			model.ExpirationDate = timetypes.NewRFC3339TimePointerValue(to.Ptr(version.Attributes.Expires.UTC()))
+		}
+		models = append(models, model)
+	}
+
+	return models
+}
+
+// latestUsableVersion returns the newest version that is enabled and whose activation and expiration dates include now,
+// or nil if there is no such version.
+func latestUsableVersion(versions []*azsecrets.SecretProperties, now time.Time) *azsecrets.SecretProperties {
+	var latest *azsecrets.SecretProperties
+	for _, version := range versions {
+		attrs := version.Attributes
+		if attrs.Enabled == nil || !*attrs.Enabled {
+			continue
+		}
+		if (attrs.NotBefore != nil && now.Before(*attrs.NotBefore)) || (attrs.Expires != nil && !now.Before(*attrs.Expires)) {
+			continue
+		}
+		if latest == nil || attrs.Created.After(*latest.Attributes.Created) {
+			latest = version
+		}
+	}
+	return latest
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewSecretVersions(t *testing.T) {
+	t.Parallel()
+
+	disabledVersion := secretProperties("version-1", 100)
+	disabledVersion.Attributes.Enabled = to.Ptr(false)
+	disabledVersion.Attributes.Expires = to.Ptr(time.Unix(300, 0))
+
+	enabledVersion := secretProperties("version-2", 200)
+	enabledVersion.Attributes.Enabled = to.Ptr(true)
+
+	got := newSecretVersions([]*azsecrets.SecretProperties{disabledVersion, enabledVersion})
+	want := []SecretVersionModel{
+		{
+			Version:        types.StringValue("version-2"),
+			ID:             types.StringValue(testVaultURL + "/secrets/secret-name/version-2"),
+			Enabled:        types.BoolValue(true),
+			CreatedDate:    timetypes.NewRFC3339TimeValue(time.Unix(200, 0).UTC()),
+			ExpirationDate: timetypes.NewRFC3339Null(),
+		},
+		{
+			Version:        types.StringValue("version-1"),
+			ID:             types.StringValue(testVaultURL + "/secrets/secret-name/version-1"),
+			Enabled:        types.BoolValue(false),
+			CreatedDate:    timetypes.NewRFC3339TimeValue(time.Unix(100, 0).UTC()),
+			ExpirationDate: timetypes.NewRFC3339TimeValue(time.Unix(300, 0).UTC()),
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newSecretVersions() = %v, want %v", got, want)
+	}
+}
+
+func TestLatestUsableVersion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	usableVersion := func(version string, created int64) *azsecrets.SecretProperties {
+		props := secretProperties(version, created)
+		props.Attributes.Enabled = to.Ptr(true)
+		return props
+	}
+
+	disabled := secretProperties("disabled", 500)
+	disabled.Attributes.Enabled = to.Ptr(false)
+	notYetActive := usableVersion("not-yet-active", 400)
+	notYetActive.Attributes.NotBefore = to.Ptr(time.Unix(2000, 0))
+	expired := usableVersion("expired", 300)
+	expired.Attributes.Expires = to.Ptr(now)
+	active := usableVersion("active", 200)
+	active.Attributes.NotBefore = to.Ptr(time.Unix(100, 0))
+	active.Attributes.Expires = to.Ptr(time.Unix(2000, 0))
+
+	tests := []struct {
+		name     string
+		versions []*azsecrets.SecretProperties
+		want     string
+	}{
+		{
+			name:     "skip unusable versions",
+			versions: []*azsecrets.SecretProperties{usableVersion("oldest", 100), disabled, notYetActive, expired, active},
+			want:     "active",
+		},
+		{
+			name:     "no usable versions",
+			versions: []*azsecrets.SecretProperties{disabled, expired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := latestUsableVersion(tt.versions, now)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("latestUsableVersion() = %q, want nil", got.ID.Version())
+				}
+				return
+			}
+			if got == nil || got.ID.Version() != tt.want {
+				t.Errorf("latestUsableVersion() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
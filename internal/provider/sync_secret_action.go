@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = (*SyncSecretAction)(nil)
+var _ action.ActionWithConfigure = (*SyncSecretAction)(nil)
+
+func NewSyncSecretAction() action.Action {
+	return &SyncSecretAction{}
+}
+
+// SyncSecretAction defines the action implementation.
+type SyncSecretAction struct {
+	client      Client
+	defaultTags map[string]string
+}
+
+type SyncSecretActionModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Version               types.String `tfsdk:"version"`
+	SourceKeyVaultID      types.String `tfsdk:"source_key_vault_id"`
+	DestinationKeyVaultID types.String `tfsdk:"destination_key_vault_id"`
+	DestinationName       types.String `tfsdk:"destination_name"`
+}
+
+func (a *SyncSecretAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sync_secret"
+}
+
+func (a *SyncSecretAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Copies a version of a Key Vault Secret from a source Key Vault to a destination Key Vault as a new version, " +
+			"including its content type, activation and expiration dates, and tags. The tags are merged with the `default_tags` and `automatic_tags` of the provider, " +
+			"and the checksum tag of `track_value_checksum` is not copied. The secret value never leaves the provider.\n\n" +
+			"~> Unlike the other resources of this provider, this action requires the `Microsoft.KeyVault/vaults/secrets/getSecret/action` permission on the source Key Vault.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Secret to copy.",
+				Required:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Specifies the version of the Key Vault Secret to copy. Defaults to the current version of the Key Vault Secret.",
+				Optional:            true,
+			},
+			"source_key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault to copy the Secret from.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"destination_key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault to copy the Secret to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"destination_name": schema.StringAttribute{
+				MarkdownDescription: "Specifies the name of the Key Vault Secret in the destination Key Vault. Defaults to `name`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *SyncSecretAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = data.Client
+	a.defaultTags = data.DefaultTags
+}
+
+func (a *SyncSecretAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model SyncSecretActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+	destinationName := name
+	if !model.DestinationName.IsNull() {
+		destinationName = model.DestinationName.ValueString()
+	}
+
+	getResp, err := a.client.GetSecret(ctx, model.SourceKeyVaultID.ValueString(), name, model.Version.ValueString(), nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Get Secret",
+			"An unexpected error occurred while getting a secret: "+err.Error()+azureErrorHint(err, dataActionGetSecret),
+		)
+		return
+	}
+
+	var attrs azsecrets.SecretAttributes
+	if getResp.Attributes != nil {
+		attrs.Enabled = getResp.Attributes.Enabled
+		attrs.Expires = getResp.Attributes.Expires
+		attrs.NotBefore = getResp.Attributes.NotBefore
+	}
+
+	setResp, err := a.client.SetSecret(ctx, model.DestinationKeyVaultID.ValueString(), destinationName, azsecrets.SetSecretParameters{
+		Value:            getResp.Value,
+		ContentType:      getResp.ContentType,
+		SecretAttributes: &attrs,
+		Tags:             copiedSecretTags(a.defaultTags, getResp.Tags),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Set Secret",
+			"An unexpected error occurred while setting a secret: "+err.Error()+azureErrorHint(err, dataActionSetSecret),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Copied the secret", map[string]any{"source_id": string(*getResp.ID), "destination_id": string(*setResp.ID)})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Copied the secret %q to %q", string(*getResp.ID), string(*setResp.ID)),
+	})
+}
@@ -0,0 +1,91 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSyncSecretAction_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: syncSecretActionConfig(rn, ""),
+			},
+			{
+				Config: syncSecretActionConfig(rn, `
+data "azurekv_secret" "destination" {
+  name         = "secret-name-${local.suffix}-copy"
+  key_vault_id = local.key_vault_id
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckResourceAttrPairs("data.azurekv_secret.destination", "azurekv_secret.test", []string{
+						"content_type",
+						"expiration_date",
+						"not_before_date",
+						"tags",
+					}),
+					resource.TestCheckResourceAttr("data.azurekv_secret.destination", "name", fmt.Sprintf("secret-name-%s-copy", rn)),
+				),
+			},
+		},
+	})
+}
+
+func syncSecretActionConfig(resourceSuffix, extraConfig string) string {
+	return fmt.Sprintf(`%s
+
+locals {
+  suffix = %q
+}
+
+resource "azurekv_secret" "test" {
+  name         = "secret-name-${local.suffix}"
+  key_vault_id = local.key_vault_id
+
+  value_wo         = "secret-value"
+  value_wo_version = 1
+
+  content_type    = "text/plain"
+  expiration_date = "2030-01-23T01:23:45Z"
+
+  tags = {
+    environment = "test"
+  }
+}
+
+action "azurekv_sync_secret" "test" {
+  config {
+    name                     = azurekv_secret.test.name
+    source_key_vault_id      = azurekv_secret.test.key_vault_id
+    destination_key_vault_id = local.key_vault_id
+    destination_name         = "${azurekv_secret.test.name}-copy"
+  }
+}
+
+resource "terraform_data" "test" {
+  input = azurekv_secret.test.version
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.azurekv_sync_secret.test]
+    }
+  }
+}
+%s`, providersConfig(resourceSuffix), resourceSuffix, extraConfig)
+}
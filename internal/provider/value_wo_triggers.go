@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// valueWOTriggers holds value_wo_version, value_wo_trigger, and triggers, one of which triggers an update for value_wo.
+type valueWOTriggers struct {
+	version  types.Dynamic
+	trigger  types.String
+	triggers types.Map
+}
+
+// specified reports whether any of the triggers is specified.
+func (t valueWOTriggers) specified() bool {
+	return !t.version.IsNull() || !t.trigger.IsNull() || !t.triggers.IsNull()
+}
+
+// changedFrom reports whether the trigger changes from the state and which one changes.
+// Switching from one to the other doesn't update the secret value.
+func (t valueWOTriggers) changedFrom(state valueWOTriggers) (bool, string) {
+	if !t.version.IsNull() && !state.version.IsNull() && !t.version.Equal(state.version) {
+		return true, "value_wo_version"
+	}
+	if !t.trigger.IsNull() && !state.trigger.IsNull() && !t.trigger.Equal(state.trigger) {
+		return true, "value_wo_trigger"
+	}
+	if !t.triggers.IsNull() && !state.triggers.IsNull() && !t.triggers.Equal(state.triggers) {
+		return true, "triggers"
+	}
+	return false, ""
+}
+
+// validateValueWOVersion validates that value_wo_version is an integer or a string.
+func validateValueWOVersion(ctx context.Context, version types.Dynamic) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch v := version.UnderlyingValue().(type) {
+	case nil, types.String:
+	case types.Number:
+		if !v.IsNull() && !v.IsUnknown() && !v.ValueBigFloat().IsInt() {
+			diags.AddAttributeError(
+				path.Root("value_wo_version"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("value_wo_version must be an integer or a string, got: %s", v.ValueBigFloat().Text('g', -1)),
+			)
+		}
+	default:
+		diags.AddAttributeError(
+			path.Root("value_wo_version"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("value_wo_version must be an integer or a string, got a value of the type %s", v.Type(ctx)),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValueWOTriggersChangedFrom(t *testing.T) {
+	t.Parallel()
+
+	version := func(v int64) types.Dynamic { return types.DynamicValue(types.NumberValue(big.NewFloat(float64(v)))) }
+	triggers := func(v string) types.Map {
+		return types.MapValueMust(types.StringType, map[string]attr.Value{"app": types.StringValue(v)})
+	}
+	null := valueWOTriggers{version: types.DynamicNull(), trigger: types.StringNull(), triggers: types.MapNull(types.StringType)}
+	with := func(f func(*valueWOTriggers)) valueWOTriggers {
+		v := null
+		f(&v)
+		return v
+	}
+
+	tests := []struct {
+		name     string
+		config   valueWOTriggers
+		state    valueWOTriggers
+		want     bool
+		wantAttr string
+	}{
+		{
+			name:   "unchanged",
+			config: with(func(v *valueWOTriggers) { v.version = version(1) }),
+			state:  with(func(v *valueWOTriggers) { v.version = version(1) }),
+		},
+		{
+			name:     "value_wo_version changes",
+			config:   with(func(v *valueWOTriggers) { v.version = version(2) }),
+			state:    with(func(v *valueWOTriggers) { v.version = version(1) }),
+			want:     true,
+			wantAttr: "value_wo_version",
+		},
+		{
+			name:     "value_wo_version changes from an integer to a string",
+			config:   with(func(v *valueWOTriggers) { v.version = types.DynamicValue(types.StringValue("1")) }),
+			state:    with(func(v *valueWOTriggers) { v.version = version(1) }),
+			want:     true,
+			wantAttr: "value_wo_version",
+		},
+		{
+			name:     "value_wo_trigger changes",
+			config:   with(func(v *valueWOTriggers) { v.trigger = types.StringValue("b") }),
+			state:    with(func(v *valueWOTriggers) { v.trigger = types.StringValue("a") }),
+			want:     true,
+			wantAttr: "value_wo_trigger",
+		},
+		{
+			name:     "triggers change",
+			config:   with(func(v *valueWOTriggers) { v.triggers = triggers("v2") }),
+			state:    with(func(v *valueWOTriggers) { v.triggers = triggers("v1") }),
+			want:     true,
+			wantAttr: "triggers",
+		},
+		{
+			name:   "switched from value_wo_version to value_wo_trigger",
+			config: with(func(v *valueWOTriggers) { v.trigger = types.StringValue("a") }),
+			state:  with(func(v *valueWOTriggers) { v.version = version(1) }),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotAttr := tt.config.changedFrom(tt.state)
+			if got != tt.want || gotAttr != tt.wantAttr {
+				t.Errorf("changedFrom() = (%v, %q), want (%v, %q)", got, gotAttr, tt.want, tt.wantAttr)
+			}
+		})
+	}
+}
+
+func TestValidateValueWOVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version types.Dynamic
+		wantErr bool
+	}{
+		{name: "null", version: types.DynamicNull()},
+		{name: "integer", version: types.DynamicValue(types.NumberValue(big.NewFloat(1)))},
+		{name: "string", version: types.DynamicValue(types.StringValue("2025-01-23"))},
+		{name: "fraction", version: types.DynamicValue(types.NumberValue(big.NewFloat(1.5))), wantErr: true},
+		{name: "bool", version: types.DynamicValue(types.BoolValue(true)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateValueWOVersion(context.Background(), tt.version)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("validateValueWOVersion() = %v, wantErr %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = (*VaultHealthDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*VaultHealthDataSource)(nil)
+
+func NewVaultHealthDataSource() datasource.DataSource {
+	return &VaultHealthDataSource{}
+}
+
+// VaultHealthDataSource defines the data source implementation.
+type VaultHealthDataSource struct {
+	client            Client
+	defaultKeyVaultID string
+}
+
+type VaultHealthDataSourceModel struct {
+	KeyVaultID        types.String `tfsdk:"key_vault_id"`
+	ID                types.String `tfsdk:"id"`
+	Healthy           types.Bool   `tfsdk:"healthy"`
+	EndpointReachable types.Bool   `tfsdk:"endpoint_reachable"`
+	EndpointError     types.String `tfsdk:"endpoint_error"`
+	TokenAcquired     types.Bool   `tfsdk:"token_acquired"`
+	TokenError        types.String `tfsdk:"token_error"`
+	ListAllowed       types.Bool   `tfsdk:"list_allowed"`
+	ListError         types.String `tfsdk:"list_error"`
+}
+
+// setHealth sets the results of the checks, in which the errors are null if the checks passed.
+func (m *VaultHealthDataSourceModel) setHealth(health VaultHealth) {
+	m.EndpointReachable, m.EndpointError = healthCheckResult(health.EndpointError, "")
+	m.TokenAcquired, m.TokenError = healthCheckResult(health.TokenError, "")
+	m.ListAllowed, m.ListError = healthCheckResult(health.ListError, dataActionReadMetadata)
+	m.Healthy = types.BoolValue(health.EndpointError == nil && health.TokenError == nil && health.ListError == nil)
+}
+
+func healthCheckResult(err error, dataAction string) (types.Bool, types.String) {
+	if err == nil {
+		return types.BoolValue(true), types.StringNull()
+	}
+	msg := err.Error()
+	if dataAction != "" && !errors.Is(err, errVaultHealthCheckSkipped) {
+		msg += azureErrorHint(err, dataAction)
+	}
+	return types.BoolValue(false), types.StringValue(msg)
+}
+
+func (d *VaultHealthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_health"
+}
+
+func (d *VaultHealthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to check that a Key Vault can be used from the environment running Terraform, " +
+			"namely that its endpoint is reachable, that an access token can be acquired, and that its secrets can be listed.\n\n" +
+			"The checks never fail the data source, so combine the results with a `check` block or a postcondition to fail smoke tests with the precise reasons.",
+
+		Attributes: map[string]schema.Attribute{
+			"key_vault_id": schema.StringAttribute{
+				MarkdownDescription: "Specifies the ID of the Key Vault to check, available on the `azurerm_key_vault` Data Source / Resource. " +
+					"If not specified, `key_vault_id` of the provider is used.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(keyVaultIDRegex, ""),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Key Vault.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether all the checks passed.",
+				Computed:            true,
+			},
+			"endpoint_reachable": schema.BoolAttribute{
+				MarkdownDescription: "Whether the endpoint of the Key Vault responded, regardless of the status code.",
+				Computed:            true,
+			},
+			"endpoint_error": schema.StringAttribute{
+				MarkdownDescription: "The reason why the endpoint of the Key Vault is unreachable, such as a DNS or firewall error.",
+				Computed:            true,
+			},
+			"token_acquired": schema.BoolAttribute{
+				MarkdownDescription: "Whether an access token for Key Vault was acquired with the credential of the provider.",
+				Computed:            true,
+			},
+			"token_error": schema.StringAttribute{
+				MarkdownDescription: "The reason why an access token can't be acquired.",
+				Computed:            true,
+			},
+			"list_allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the secrets in the Key Vault can be listed, which requires the `Microsoft.KeyVault/vaults/secrets/readMetadata/action` permission. " +
+					"This check is skipped if either of the other checks fails.",
+				Computed: true,
+			},
+			"list_error": schema.StringAttribute{
+				MarkdownDescription: "The reason why the secrets in the Key Vault can't be listed, such as missing permissions.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *VaultHealthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+	d.defaultKeyVaultID = data.DefaultKeyVaultID
+}
+
+func (d *VaultHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VaultHealthDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyVaultID.IsNull() {
+		if d.defaultKeyVaultID == "" {
+			resp.Diagnostics.AddError("Missing Key Vault", "key_vault_id must be specified unless key_vault_id is set on the provider.")
+			return
+		}
+		model.KeyVaultID = types.StringValue(d.defaultKeyVaultID)
+	}
+	model.ID = model.KeyVaultID
+
+	health := d.client.CheckVaultHealth(ctx, model.KeyVaultID.ValueString())
+	model.setHealth(health)
+	tflog.Debug(ctx, "Checked the health of the key vault", map[string]any{"key_vault_id": model.KeyVaultID.ValueString(), "healthy": model.Healthy.ValueBool()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,46 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVaultHealthDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	rn := generateRandomName(23)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {
+				Source:            "hashicorp/azurerm",
+				VersionConstraint: azurermAcceptanceTestVersion,
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: vaultHealthDataSourceConfig(rn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.azurekv_vault_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.azurekv_vault_health.test", "endpoint_reachable", "true"),
+					resource.TestCheckResourceAttr("data.azurekv_vault_health.test", "token_acquired", "true"),
+					resource.TestCheckResourceAttr("data.azurekv_vault_health.test", "list_allowed", "true"),
+					resource.TestCheckNoResourceAttr("data.azurekv_vault_health.test", "list_error"),
+				),
+			},
+		},
+	})
+}
+
+func vaultHealthDataSourceConfig(resourceSuffix string) string {
+	return fmt.Sprintf(`%s
+
+data "azurekv_vault_health" "test" {
+  key_vault_id = local.key_vault_id
+}
+`, providersConfig(resourceSuffix))
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 	"regexp"
 
 	azlog "github.com/Azure/azure-sdk-for-go/sdk/azcore/log"
 	"github.com/abicky/terraform-provider-azurekv/internal/provider"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -28,8 +30,15 @@ func main() {
 	// Omit timestamps because logs that does not start with a log level are ignored
 	// cf. https://github.com/hashicorp/go-plugin/blob/v1.6.3/client.go#L1202-L1217
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
+	events, err := provider.ParseLogEvents(os.Getenv(provider.LogEventsEnvVar))
+	if err != nil {
+		log.Print("[WARN] " + err.Error())
+	}
+	if len(events) > 0 {
+		azlog.SetEvents(events...)
+	}
 	azlog.SetListener(func(event azlog.Event, msg string) {
-		log.Print(beginningOfLineRegexp.ReplaceAllLiteralString(string(event)+" "+msg, "[DEBUG] "))
+		log.Print(beginningOfLineRegexp.ReplaceAllLiteralString(string(event)+" "+provider.RedactLogMessage(msg), "[DEBUG] "))
 	})
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -40,7 +49,13 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// Serve the same provider so that the metrics of its clients can be reported after the server stops
+	p := provider.New(version)().(*provider.AzurekvProvider)
+	err = providerserver.Serve(context.Background(), func() fwprovider.Provider { return p }, opts)
+	if err := provider.ShutdownTracing(context.Background()); err != nil {
+		log.Print("[WARN] Failed to shut down tracing: " + err.Error())
+	}
+	p.ReportMetrics()
 
 	if err != nil {
 		log.Fatal(err.Error())